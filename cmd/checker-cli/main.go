@@ -0,0 +1,393 @@
+// Command checker-cli is a headless, scriptable front-end for the checker
+// engine. It exposes the same capabilities as cmd/gui (combo/proxy/config
+// paths, worker count, timeout, auto-scrape, config selection) as flags
+// instead of widgets, sharing the GUI's bounds-checking rules via
+// internal/inputs so a value accepted by one front-end is accepted by the
+// other. Where the GUI renders a progress bar and log pane, this prints a
+// live progress bar and periodic stats snapshot to stderr and streams valid
+// hits to stdout, so a run can sit in a shell pipeline.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/gorilla/websocket"
+
+	"universal-checker/internal/checker"
+	"universal-checker/internal/config"
+	"universal-checker/internal/inputs"
+	"universal-checker/pkg/types"
+)
+
+// statsPollInterval matches the GUI's original 2-second GetStats ticker; the
+// CLI polls GetStats at this interval for its progress line and its
+// completion check.
+const statsPollInterval = 2 * time.Second
+
+// supportedConfigExts mirrors the GUI's selectConfigFiles format check.
+var supportedConfigExts = map[string]bool{".opk": true, ".svb": true, ".loli": true}
+
+// Options is the top-level flag set; each verb below is a go-flags command
+// hung off it.
+type Options struct {
+	Run             RunCommand             `command:"run" description:"Load combos/proxies/configs and start a checking run"`
+	ValidateConfigs ValidateConfigsCommand `command:"validate-configs" description:"Parse one or more config files and report errors"`
+	ScrapeProxies   ScrapeProxiesCommand   `command:"scrape-proxies" description:"Auto-scrape and print working proxies, without running any configs"`
+	StatsTail       StatsTailCommand       `command:"stats-tail" description:"Tail the live /ws/stats feed of a running checker's stream server"`
+}
+
+// RunCommand starts a checking run to completion, the CLI equivalent of the
+// GUI's startChecking/runChecker pair.
+type RunCommand struct {
+	ComboFile     string   `short:"c" long:"combo" required:"true" description:"Path to the combo (email:pass) file"`
+	ProxyFile     string   `short:"p" long:"proxy" description:"Path to a proxy list file; omit with --auto-scrape to scrape instead"`
+	ConfigFiles   []string `short:"g" long:"config" required:"true" description:"Config file (.opk/.svb/.loli); repeat for multiple, together with --all-configs"`
+	AllConfigs    bool     `long:"all-configs" description:"Run every --config given, equivalent to the GUI's Select All checkbox"`
+	Workers       int      `short:"w" long:"workers" default:"100" description:"Worker pool size (1-1000)"`
+	Timeout       int      `short:"t" long:"timeout" default:"30000" description:"Request timeout in milliseconds (1000-300000)"`
+	AutoScrape    bool     `long:"auto-scrape" description:"Auto-scrape proxies when --proxy is omitted"`
+	SaveValidOnly bool     `long:"valid-only" description:"Only persist valid results to --output-dir"`
+	OutputDir     string   `long:"output-dir" default:"results" description:"Directory the checker writes persisted results to"`
+	HitFormat     string   `long:"hit-format" default:"txt" choice:"txt" choice:"json" choice:"ndjson" description:"Format for the valid-hit stream written to stdout"`
+	StreamAddr    string   `long:"stream-addr" default:"127.0.0.1:18099" description:"Local address for the checker's live stream server, used to tail hits/stats"`
+}
+
+// ValidateConfigsCommand runs every given path through config.NewParser and
+// reports which ones fail, without touching combos, proxies, or the network.
+type ValidateConfigsCommand struct {
+	Positional struct {
+		ConfigFiles []string `positional-arg-name:"config-file" required:"1"`
+	} `positional-args:"yes"`
+}
+
+// ScrapeProxiesCommand runs just the checker's proxy auto-scrape and prints
+// the working proxies it found, one per line.
+type ScrapeProxiesCommand struct{}
+
+// StatsTailCommand dials a running checker's /ws/stats endpoint (e.g. one
+// started by `run --stream-addr`) and prints each snapshot.
+type StatsTailCommand struct {
+	Positional struct {
+		Addr string `positional-arg-name:"host:port" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func main() {
+	var opts Options
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "checker-cli"
+	parser.LongDescription = "Headless front-end for the checker engine; run `checker-cli <command> --help` for details."
+
+	if _, err := parser.Parse(); err != nil {
+		if flagsErr, ok := err.(*flags.Error); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	}
+}
+
+// Execute implements flags.Commander, invoked by go-flags once the `run`
+// command's flags have parsed successfully.
+func (cmd *RunCommand) Execute(args []string) error {
+	workers := inputs.ValidateNumeric(fmt.Sprintf("%d", cmd.Workers), inputs.WorkersBounds)
+	timeout := inputs.ValidateNumeric(fmt.Sprintf("%d", cmd.Timeout), inputs.TimeoutBounds)
+
+	configs, err := loadConfigs(cmd.ConfigFiles)
+	if err != nil {
+		return err
+	}
+	if !cmd.AllConfigs && len(configs) > 1 {
+		return fmt.Errorf("multiple --config given without --all-configs; pass --all-configs to run them all")
+	}
+
+	proxyPath := cmd.ProxyFile
+	if proxyPath == "" && !cmd.AutoScrape {
+		return fmt.Errorf("either --proxy or --auto-scrape is required")
+	}
+
+	if err := (inputs.RunInputs{
+		ComboPath:      cmd.ComboFile,
+		ProxyPath:      proxyPath,
+		SelectedConfig: len(configs),
+	}).Validate(); err != nil {
+		return err
+	}
+
+	checkerConfig := &types.CheckerConfig{
+		MaxWorkers:        workers,
+		ProxyTimeout:      5000,
+		RequestTimeout:    timeout,
+		RetryCount:        3,
+		ProxyRotation:     true,
+		AutoScrapeProxies: cmd.AutoScrape && proxyPath == "",
+		SaveValidOnly:     cmd.SaveValidOnly,
+		OutputFormat:      "txt",
+		OutputDirectory:   cmd.OutputDir,
+		StreamServerAddr:  cmd.StreamAddr,
+	}
+
+	ck := checker.NewChecker(checkerConfig)
+	ck.Configs = configs
+
+	fmt.Fprintln(os.Stderr, "Loading combos...")
+	if err := ck.LoadCombos(cmd.ComboFile); err != nil {
+		return fmt.Errorf("loading combos: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Loaded %d combos\n", len(ck.Combos))
+
+	if err := ck.LoadProxies(proxyPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load proxies: %v\n", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "Loaded %d proxies\n", len(ck.Proxies))
+	}
+
+	if err := ck.Start(); err != nil {
+		return fmt.Errorf("starting checker: %w", err)
+	}
+	defer ck.Stop()
+
+	done := make(chan struct{})
+	go tailHits(cmd.StreamAddr, cmd.HitFormat, done)
+	go printProgress(ck, len(ck.Combos)*len(configs), done)
+
+	<-waitForCompletion(ck)
+	close(done)
+	return nil
+}
+
+// waitForCompletion polls GetStats until every combo/config pairing has been
+// processed, then signals on the returned channel.
+func waitForCompletion(ck *checker.Checker) <-chan struct{} {
+	out := make(chan struct{})
+	go func() {
+		defer close(out)
+		total := len(ck.Combos) * len(ck.Configs)
+		if total == 0 {
+			return
+		}
+		ticker := time.NewTicker(statsPollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			stats := ck.GetStats()
+			if stats.ValidCombos+stats.InvalidCombos+stats.ErrorCombos >= total {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// printProgress writes a single-line, carriage-return-updated progress bar
+// plus a stats snapshot to stderr every statsPollInterval, mirroring the
+// GUI's stats panel but rendered for a terminal instead of a widget.
+func printProgress(ck *checker.Checker, total int, done <-chan struct{}) {
+	ticker := time.NewTicker(statsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			stats := ck.GetStats()
+			processed := stats.ValidCombos + stats.InvalidCombos + stats.ErrorCombos
+
+			percent := 0.0
+			if total > 0 {
+				percent = float64(processed) / float64(total) * 100
+			}
+
+			fmt.Fprintf(os.Stderr, "\r[%s] %5.1f%% | valid=%d invalid=%d error=%d | cpm=%.1f",
+				progressBar(percent, 30), percent, stats.ValidCombos, stats.InvalidCombos, stats.ErrorCombos, stats.CurrentCPM)
+		}
+	}
+}
+
+// progressBar renders a simple `#`-filled bar of the given width.
+func progressBar(percent float64, width int) string {
+	filled := int(percent / 100 * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("#", filled) + strings.Repeat("-", width-filled)
+}
+
+// tailHits dials the checker's own /ws/results feed and writes each valid
+// hit to stdout in the requested format, so a run can sit in a shell
+// pipeline without a Fyne window to render the GUI's log pane.
+func tailHits(addr, format string, done <-chan struct{}) {
+	url := fmt.Sprintf("ws://%s/ws/results", addr)
+
+	var conn *websocket.Conn
+	var err error
+	for i := 0; i < 10; i++ {
+		conn, _, err = websocket.DefaultDialer.Dial(url, nil)
+		if err == nil {
+			break
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "results stream unavailable, hits will only be written to --output-dir: %v\n", err)
+		return
+	}
+	defer conn.Close()
+
+	writer := bufio.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	go func() {
+		<-done
+		conn.Close()
+	}()
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var result types.CheckResult
+		if err := json.Unmarshal(payload, &result); err != nil {
+			continue
+		}
+		if result.Status != types.BotStatusSuccess {
+			continue
+		}
+
+		writeHit(writer, result, format)
+		writer.Flush()
+	}
+}
+
+func writeHit(w *bufio.Writer, result types.CheckResult, format string) {
+	switch format {
+	case "json", "ndjson":
+		payload, err := json.Marshal(result)
+		if err != nil {
+			return
+		}
+		w.Write(payload)
+		w.WriteByte('\n')
+	default: // txt
+		fmt.Fprintf(w, "%s | %s\n", result.Combo, result.Config)
+	}
+}
+
+// Execute parses every given config path and reports the first error found
+// for each, so the caller can fix a whole batch in one pass instead of one
+// typo at a time.
+func (cmd *ValidateConfigsCommand) Execute(args []string) error {
+	var failed int
+	for _, path := range cmd.Positional.ConfigFiles {
+		if ext := strings.ToLower(extOf(path)); !supportedConfigExts[ext] {
+			fmt.Fprintf(os.Stderr, "%s: unsupported config format: %s\n", path, ext)
+			failed++
+			continue
+		}
+
+		parser := config.NewParser()
+		cfg, err := parser.ParseConfig(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s: OK (%s, %s)\n", path, cfg.Name, strings.ToUpper(string(cfg.Type)))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d configs failed validation", failed, len(cmd.Positional.ConfigFiles))
+	}
+	return nil
+}
+
+// Execute starts a throwaway checker just to drive its auto-scrape proxy
+// path, then prints every working proxy it found.
+func (cmd *ScrapeProxiesCommand) Execute(args []string) error {
+	ck := checker.NewChecker(&types.CheckerConfig{
+		MaxWorkers:        1,
+		ProxyTimeout:      5000,
+		RequestTimeout:    30000,
+		AutoScrapeProxies: true,
+		OutputDirectory:   "results",
+		OutputFormat:      "txt",
+	})
+
+	if err := ck.LoadProxies(""); err != nil {
+		return fmt.Errorf("scraping proxies: %w", err)
+	}
+
+	for _, p := range ck.Proxies {
+		fmt.Printf("%s:%d\n", p.Host, p.Port)
+	}
+	fmt.Fprintf(os.Stderr, "Scraped %d working proxies\n", len(ck.Proxies))
+	return nil
+}
+
+// Execute dials addr's /ws/stats endpoint and prints each pushed snapshot
+// until the connection closes or the process is interrupted.
+func (cmd *StatsTailCommand) Execute(args []string) error {
+	url := fmt.Sprintf("ws://%s/ws/stats", cmd.Positional.Addr)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return fmt.Errorf("dialing %s: %w", url, err)
+	}
+	defer conn.Close()
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return nil
+		}
+
+		var stats types.CheckerStats
+		if err := json.Unmarshal(payload, &stats); err != nil {
+			continue
+		}
+
+		processed := stats.ValidCombos + stats.InvalidCombos + stats.ErrorCombos
+		fmt.Printf("processed=%d valid=%d invalid=%d error=%d cpm=%.1f\n",
+			processed, stats.ValidCombos, stats.InvalidCombos, stats.ErrorCombos, stats.CurrentCPM)
+	}
+}
+
+// extOf returns path's extension from the last '.' on, unchanged in case
+// (callers lowercase it), without pulling in path/filepath just for this.
+func extOf(path string) string {
+	idx := strings.LastIndex(path, ".")
+	if idx < 0 {
+		return ""
+	}
+	return path[idx:]
+}
+
+// loadConfigs parses every path in paths, stopping at the first failure -
+// matching the GUI's selectConfigFiles behavior of surfacing one error at a
+// time via a dialog.
+func loadConfigs(paths []string) ([]types.Config, error) {
+	parser := config.NewParser()
+	configs := make([]types.Config, 0, len(paths))
+
+	for _, path := range paths {
+		ext := strings.ToLower(extOf(path))
+		if !supportedConfigExts[ext] {
+			return nil, fmt.Errorf("unsupported config format: %s", ext)
+		}
+
+		cfg, err := parser.ParseConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+		configs = append(configs, *cfg)
+	}
+
+	return configs, nil
+}
@@ -2,9 +2,7 @@ package main
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +15,8 @@ import (
 
 	"universal-checker/internal/checker"
 	"universal-checker/internal/config"
+	"universal-checker/internal/inputs"
+	"universal-checker/internal/workers"
 	"universal-checker/pkg/types"
 )
 
@@ -41,6 +41,10 @@ type GUI struct {
 	// Status components
 	statusLabel *widget.Label
 	progressBar *widget.ProgressBar
+	validBar    *widget.ProgressBar
+	invalidBar  *widget.ProgressBar
+	errorBar    *widget.ProgressBar
+	etaLabel    *widget.Label
 	statsLabel  *widget.RichText
 	logArea     *widget.RichText
 
@@ -59,10 +63,10 @@ type GUI struct {
 	selectedConfigs map[int]bool
 
 	// Resource management
-	statsUpdateTicker *time.Ticker
-	statsUpdateDone   chan bool
-	logBuffer         []string
-	maxLogLines       int
+	statsStreamDone chan bool
+	logBuffer       []string
+	maxLogLines     int
+	cpmEMA          float64 // exponential moving average of CurrentCPM, for ETA
 
 	// UI update channel for thread safety
 	uiUpdateChan chan func()
@@ -86,7 +90,7 @@ func NewGUI() *GUI {
 		configPaths:     make([]string, 0),
 		selectedConfigs: make(map[int]bool),
 		isRunning:       false,
-		statsUpdateDone: make(chan bool, 1),
+		statsStreamDone: make(chan bool, 1),
 		logBuffer:       make([]string, 0),
 		maxLogLines:     1000,
 		uiUpdateChan:    make(chan func(), 100),
@@ -175,12 +179,8 @@ func (g *GUI) cleanup() {
 		g.stopCheckerInternal()
 	}
 
-	if g.statsUpdateTicker != nil {
-		g.statsUpdateTicker.Stop()
-	}
-
 	select {
-	case g.statsUpdateDone <- true:
+	case g.statsStreamDone <- true:
 	default:
 	}
 
@@ -278,10 +278,14 @@ func (g *GUI) createSettingsSection() *fyne.Container {
 
 	settingsLabel := widget.NewLabelWithStyle("Settings", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 
-	// Workers setting
+	// Workers setting. Before a run starts, this only seeds
+	// CheckerConfig.MaxWorkers (see startChecking). Once a run is live,
+	// submitting a new value calls checker.Resize on the combo-check pool
+	// directly, so the worker count can be retuned without restarting.
 	workersLabel := widget.NewLabel("Workers:")
 	g.workersEntry = widget.NewEntry()
 	g.workersEntry.SetText("100")
+	g.workersEntry.OnSubmitted = func(text string) { g.resizeWorkerPool(text) }
 	workersRow := container.NewBorder(nil, nil, workersLabel, nil, g.workersEntry)
 
 	// Timeout setting
@@ -326,6 +330,19 @@ func (g *GUI) createStatusSection() *fyne.Container {
 	g.progressBar = widget.NewProgressBar()
 	g.progressBar.Hide()
 
+	// Multi-segment breakdown (valid/invalid/error share of combos processed so far)
+	g.validBar = widget.NewProgressBar()
+	g.invalidBar = widget.NewProgressBar()
+	g.errorBar = widget.NewProgressBar()
+	breakdown := container.NewGridWithColumns(3,
+		container.NewVBox(widget.NewLabel("Valid"), g.validBar),
+		container.NewVBox(widget.NewLabel("Invalid"), g.invalidBar),
+		container.NewVBox(widget.NewLabel("Errors"), g.errorBar),
+	)
+
+	// ETA, driven by an exponential moving average of CurrentCPM
+	g.etaLabel = widget.NewLabel("")
+
 	// Statistics
 	g.statsLabel = widget.NewRichTextFromMarkdown("")
 	g.statsLabel.Resize(fyne.NewSize(400, 100))
@@ -339,6 +356,8 @@ func (g *GUI) createStatusSection() *fyne.Container {
 
 	section.Add(g.statusLabel)
 	section.Add(g.progressBar)
+	section.Add(breakdown)
+	section.Add(g.etaLabel)
 	section.Add(g.statsLabel)
 	section.Add(logLabel)
 	section.Add(logScroll)
@@ -352,56 +371,25 @@ func (g *GUI) setupDragAndDrop() {
 	// For now, users will use the browse buttons
 }
 
-// validateNumericInput validates and sanitizes numeric input with bounds checking
+// validateNumericInput validates and sanitizes numeric input with bounds
+// checking, delegating to the bounds both front-ends share.
 func (g *GUI) validateNumericInput(input string, defaultValue, min, max int) int {
-	if strings.TrimSpace(input) == "" {
-		return defaultValue
-	}
-
-	value, err := strconv.Atoi(strings.TrimSpace(input))
-	if err != nil {
-		return defaultValue
-	}
-
-	if value < min {
-		return min
-	}
-	if value > max {
-		return max
-	}
-
-	return value
+	return inputs.ValidateNumeric(input, inputs.NumericBounds{Default: defaultValue, Min: min, Max: max})
 }
 
-// validateInputs validates all user inputs before starting the checker
+// validateInputs validates all user inputs before starting the checker,
+// via the same RunInputs rules the CLI front-end enforces.
 func (g *GUI) validateInputs() error {
-	if g.comboPath == "" {
-		return fmt.Errorf("please select a combo file")
-	}
-
-	if !g.fileExists(g.comboPath) {
-		return fmt.Errorf("combo file does not exist: %s", g.comboPath)
-	}
-
-	if g.proxyPath != "" && !g.fileExists(g.proxyPath) {
-		return fmt.Errorf("proxy file does not exist: %s", g.proxyPath)
-	}
-
-	selectedConfigs := g.getSelectedConfigs()
-	if len(selectedConfigs) == 0 {
-		return fmt.Errorf("please select at least one configuration")
-	}
-
-	return nil
+	return inputs.RunInputs{
+		ComboPath:      g.comboPath,
+		ProxyPath:      g.proxyPath,
+		SelectedConfig: len(g.getSelectedConfigs()),
+	}.Validate()
 }
 
 // fileExists checks if a file exists and is readable
 func (g *GUI) fileExists(path string) bool {
-	if path == "" {
-		return false
-	}
-	_, err := os.Stat(path)
-	return err == nil
+	return inputs.FileExists(path)
 }
 
 func (g *GUI) selectComboFile() {
@@ -528,6 +516,7 @@ func (g *GUI) startChecking() {
 
 	// Create checker instance
 	g.checker = checker.NewChecker(checkerConfig)
+	g.cpmEMA = 0
 
 	// Set only selected configs
 	g.checker.Configs = g.getSelectedConfigs()
@@ -539,6 +528,28 @@ func (g *GUI) startChecking() {
 	go g.runChecker()
 }
 
+// resizeWorkerPool live-tunes the combo-check pool's size while a run is in
+// progress. Before a run starts (g.checker nil, or not running) it's a
+// no-op - startChecking reads workersEntry itself when it builds the
+// CheckerConfig for the next run.
+func (g *GUI) resizeWorkerPool(text string) {
+	g.mutex.RLock()
+	ck := g.checker
+	isRunning := g.isRunning
+	g.mutex.RUnlock()
+
+	if !isRunning || ck == nil {
+		return
+	}
+
+	n := g.validateNumericInput(text, 100, 1, 1000)
+	g.workersEntry.SetText(fmt.Sprintf("%d", n))
+
+	if err := ck.Resize(checker.PoolComboCheck, n); err != nil {
+		g.logMessage(fmt.Sprintf("Failed to resize worker pool: %v", err))
+	}
+}
+
 // stopChecking stops the checking process
 func (g *GUI) stopChecking() {
 	g.mutex.Lock()
@@ -559,13 +570,8 @@ func (g *GUI) stopCheckerInternal() {
 		g.checker.Stop()
 	}
 
-	if g.statsUpdateTicker != nil {
-		g.statsUpdateTicker.Stop()
-		g.statsUpdateTicker = nil
-	}
-
 	select {
-	case g.statsUpdateDone <- true:
+	case g.statsStreamDone <- true:
 	default:
 	}
 }
@@ -624,35 +630,37 @@ func (g *GUI) runChecker() {
 		return
 	}
 
-	// Update status periodically
-	go g.updateStats()
+	// Subscribe to live stats pushed by the checker
+	go g.subscribeStats()
 }
 
-// updateStats safely updates statistics with thread safety and division by zero protection
-func (g *GUI) updateStats() {
-	g.statsUpdateTicker = time.NewTicker(2 * time.Second)
-	defer func() {
-		if g.statsUpdateTicker != nil {
-			g.statsUpdateTicker.Stop()
-		}
-	}()
+// cpmEMAAlpha weights how quickly the ETA's CPM estimate reacts to the
+// latest sample versus its own history. Lower is smoother, higher tracks
+// bursts more closely.
+const cpmEMAAlpha = 0.3
+
+// subscribeStats replaces polling with a subscription to the checker's
+// StatsStream, rendering each pushed snapshot as soon as it arrives instead
+// of on a fixed 2-second tick.
+func (g *GUI) subscribeStats() {
+	g.mutex.RLock()
+	checker := g.checker
+	g.mutex.RUnlock()
 
+	if checker == nil {
+		return
+	}
+
+	stream := checker.StatsStream()
 	for {
 		select {
-		case <-g.statsUpdateTicker.C:
-			g.mutex.RLock()
-			isRunning := g.isRunning
-			checker := g.checker
-			g.mutex.RUnlock()
-
-			if !isRunning || checker == nil {
+		case stats, ok := <-stream:
+			if !ok {
 				return
 			}
-
-			stats := checker.GetStats()
 			g.updateUIWithStats(stats)
 
-		case <-g.statsUpdateDone:
+		case <-g.statsStreamDone:
 			return
 		}
 	}
@@ -660,6 +668,15 @@ func (g *GUI) updateStats() {
 
 // updateUIWithStats safely updates UI elements with statistics
 func (g *GUI) updateUIWithStats(stats types.CheckerStats) {
+	g.mutex.Lock()
+	if g.cpmEMA == 0 {
+		g.cpmEMA = stats.CurrentCPM
+	} else {
+		g.cpmEMA = cpmEMAAlpha*stats.CurrentCPM + (1-cpmEMAAlpha)*g.cpmEMA
+	}
+	cpmEMA := g.cpmEMA
+	g.mutex.Unlock()
+
 	g.scheduleUIUpdate(func() {
 		// Update status
 		g.statusLabel.SetText(fmt.Sprintf("Running - CPM: %.1f", stats.CurrentCPM))
@@ -673,12 +690,34 @@ func (g *GUI) updateUIWithStats(stats types.CheckerStats) {
 			g.progressBar.SetValue(float64(processed) / float64(totalTasks))
 		}
 
+		// Update the valid/invalid/error breakdown, each as a share of combos processed so far
+		if processed > 0 {
+			g.validBar.SetValue(float64(stats.ValidCombos) / float64(processed))
+			g.invalidBar.SetValue(float64(stats.InvalidCombos) / float64(processed))
+			g.errorBar.SetValue(float64(stats.ErrorCombos) / float64(processed))
+		}
+
+		// Update ETA from the smoothed CPM estimate
+		g.etaLabel.SetText(fmt.Sprintf("ETA: %s", g.formatETA(totalTasks-processed, cpmEMA)))
+
 		// Update stats display
 		statsText := g.formatStatsText(stats, progressPercent)
 		g.statsLabel.ParseMarkdown(statsText)
 	})
 }
 
+// formatETA estimates the remaining time from the smoothed CPM, returning a
+// placeholder until there's enough signal to produce a meaningful estimate.
+func (g *GUI) formatETA(remaining int, cpmEMA float64) string {
+	if remaining <= 0 {
+		return "done"
+	}
+	if cpmEMA <= 0 {
+		return "calculating..."
+	}
+	return g.formatDuration(int(float64(remaining) / cpmEMA * 60))
+}
+
 // calculateTotalTasks safely calculates total tasks with zero protection
 func (g *GUI) calculateTotalTasks(stats types.CheckerStats) int {
 	g.mutex.RLock()
@@ -716,6 +755,7 @@ func (g *GUI) formatStatsText(stats types.CheckerStats, progressPercent float64)
 ðŸš€ **Current CPM:** %.1f
 ðŸ‘¥ **Active Workers:** %d
 ðŸŒ **Working Proxies:** %d/%d
+ðŸ§µ **Pools:** %s
 ðŸ“ˆ **Progress:** %.1f%%`,
 		g.formatDuration(stats.ElapsedTime),
 		stats.TotalCombos,
@@ -726,9 +766,24 @@ func (g *GUI) formatStatsText(stats types.CheckerStats, progressPercent float64)
 		stats.ActiveWorkers,
 		stats.WorkingProxies,
 		stats.TotalProxies,
+		g.formatPoolStats(stats.PoolStats),
 		progressPercent)
 }
 
+// formatPoolStats renders each worker pool's live size, queue depth, and
+// recovered-panic count for the stats panel's Pools line.
+func (g *GUI) formatPoolStats(pools []workers.Metrics) string {
+	if len(pools) == 0 {
+		return "n/a"
+	}
+
+	parts := make([]string, 0, len(pools))
+	for _, p := range pools {
+		parts = append(parts, fmt.Sprintf("%s %d workers/%d queued/%d panics", p.Name, p.Size, p.QueueDepth, p.PanicsRecovered))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (g *GUI) getSelectedConfigs() []types.Config {
 	var selected []types.Config
 	for i, config := range g.configs {
@@ -749,6 +804,10 @@ func (g *GUI) clearResults() {
 		g.logArea.ParseMarkdown("")
 		g.statsLabel.ParseMarkdown("")
 		g.progressBar.SetValue(0)
+		g.validBar.SetValue(0)
+		g.invalidBar.SetValue(0)
+		g.errorBar.SetValue(0)
+		g.etaLabel.SetText("")
 		g.statusLabel.SetText("Ready")
 	})
 }
@@ -1,6 +1,10 @@
 package configs
 
-import "universal-checker/internal/logger"
+import (
+	"time"
+
+	"universal-checker/internal/logger"
+)
 
 // DevelopmentLoggerConfig returns a logger configuration optimized for development
 // Features: DEBUG level, readable text format, file output, buffering enabled
@@ -15,7 +19,9 @@ func DevelopmentLoggerConfig(component string) logger.LoggerConfig {
 }
 
 // ProductionLoggerConfig returns a logger configuration optimized for production
-// Features: INFO level, JSON format, file output, large buffer
+// Features: INFO level, JSON format, file output, large buffer, async batched
+// writes flushed and rotated every minute or at 100MB, with 10
+// gzip-compressed backups
 func ProductionLoggerConfig(component string) logger.LoggerConfig {
 	return logger.LoggerConfig{
 		Level:      logger.INFO,
@@ -23,6 +29,13 @@ func ProductionLoggerConfig(component string) logger.LoggerConfig {
 		OutputFile: "logs/production.log",
 		BufferSize: 5000,
 		Component:  component,
+		AsyncRotation: &logger.AsyncRotationConfig{
+			FlushSize:        256 * 1024,
+			RotationInterval: time.Minute,
+			MaxFileSize:      100 * 1024 * 1024,
+			MaxBackups:       10,
+			Compress:         true,
+		},
 	}
 }
 
@@ -39,7 +52,9 @@ func TestLoggerConfig(component string) logger.LoggerConfig {
 }
 
 // DebugLoggerConfig returns a logger configuration for intensive debugging
-// Features: DEBUG level, text format, file output, large buffer for analysis
+// Features: DEBUG level, text format, file output, large buffer for analysis,
+// async batched writes rotated hourly or at 250MB so a long debugging
+// session doesn't grow one huge file or block on disk I/O
 func DebugLoggerConfig(component string) logger.LoggerConfig {
 	return logger.LoggerConfig{
 		Level:      logger.DEBUG,
@@ -47,6 +62,12 @@ func DebugLoggerConfig(component string) logger.LoggerConfig {
 		OutputFile: "logs/debug.log",
 		BufferSize: 10000,
 		Component:  component,
+		AsyncRotation: &logger.AsyncRotationConfig{
+			FlushSize:        128 * 1024,
+			RotationInterval: time.Hour,
+			MaxFileSize:      250 * 1024 * 1024,
+			MaxBackups:       5,
+		},
 	}
 }
 
@@ -0,0 +1,96 @@
+// Package adminapi exposes a REST control surface over a running
+// checker.Checker: hot-reloading configs, mutating the proxy pool, and
+// scaling the worker pool, all without restarting the process. It mirrors
+// frp's /api/reload handler pattern - every mutation is validated first and
+// rolled back on failure rather than left half-applied.
+package adminapi
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"universal-checker/pkg/proxypolicy"
+	"universal-checker/pkg/types"
+)
+
+// CheckerAPI is the subset of *checker.Checker this package drives. It's
+// defined here (rather than imported from internal/checker) so checker can
+// depend on adminapi without adminapi depending back on checker.
+type CheckerAPI interface {
+	// UpdateConfig atomically applies a partial CheckerConfig update.
+	UpdateConfig(patch types.CheckerConfigPatch) error
+
+	// ListProxies returns a snapshot of the current proxy pool.
+	ListProxies() []types.Proxy
+	// AddProxy adds a proxy to the running pool.
+	AddProxy(proxy types.Proxy) error
+	// RemoveProxy removes a proxy by host:port from the running pool.
+	RemoveProxy(host string, port int) error
+
+	// ReloadConfigs re-parses configPaths and swaps them in atomically,
+	// leaving the existing configs untouched on any parse error.
+	ReloadConfigs(configPaths []string) error
+
+	// ScaleWorkers grows (positive delta) or shrinks (negative delta) the
+	// worker pool.
+	ScaleWorkers(delta int) error
+
+	// ProxyHealth returns the active/passive proxy health table, keyed by
+	// proxypolicy.ProxyKey, or nil if health-checking isn't configured.
+	ProxyHealth() map[string]proxypolicy.ProxyHealth
+}
+
+// Config controls the admin listener.
+type Config struct {
+	// Addr is the address the HTTP server listens on, e.g. ":8091".
+	Addr string
+
+	// AuthToken is the shared secret every request must present in the
+	// X-Admin-Token header. An empty token disables auth, which is only
+	// appropriate for local/trusted deployments.
+	AuthToken string
+
+	// ConfigPaths are the on-disk config paths POST /api/admin/configs/reload
+	// re-parses when the request body doesn't override them.
+	ConfigPaths []string
+}
+
+// Server hosts the admin REST API described in the package doc.
+type Server struct {
+	config     Config
+	checker    CheckerAPI
+	httpServer *http.Server
+}
+
+// NewServer creates a Server; call Start to begin listening.
+func NewServer(config Config, checker CheckerAPI) *Server {
+	return &Server{config: config, checker: checker}
+}
+
+// Start begins listening in a background goroutine.
+func (s *Server) Start() error {
+	s.httpServer = &http.Server{
+		Addr:    s.config.Addr,
+		Handler: s.authMiddleware(s.routes()),
+	}
+
+	ln, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return err
+	}
+
+	go s.httpServer.Serve(ln)
+	return nil
+}
+
+// Stop gracefully shuts the listener down.
+func (s *Server) Stop() {
+	if s.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.httpServer.Shutdown(ctx)
+}
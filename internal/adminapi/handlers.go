@@ -0,0 +1,175 @@
+package adminapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"universal-checker/pkg/types"
+)
+
+// errorResponse is the JSON body returned for every failed mutation, e.g.
+// {"error": "failed to parse config foo.loli: unexpected token"}.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// routes builds the admin API's mux. Auth is applied once, in
+// authMiddleware, rather than per-handler.
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/admin/config", s.handleConfig)
+	mux.HandleFunc("/api/admin/proxies", s.handleProxies)
+	mux.HandleFunc("/api/admin/proxies/health", s.handleProxyHealth)
+	mux.HandleFunc("/api/admin/configs/reload", s.handleConfigsReload)
+	mux.HandleFunc("/api/admin/workers/scale", s.handleWorkersScale)
+	return mux
+}
+
+// authMiddleware rejects any request that doesn't present the configured
+// shared secret. An empty AuthToken disables the check.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.config.AuthToken != "" && r.Header.Get("X-Admin-Token") != s.config.AuthToken {
+			writeError(w, http.StatusUnauthorized, "invalid or missing X-Admin-Token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleConfig handles PUT /api/admin/config, atomically swapping in the
+// CheckerConfig fields present in the request body.
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, "expected PUT")
+		return
+	}
+
+	var patch types.CheckerConfigPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if err := s.checker.UpdateConfig(patch); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleProxies handles GET/POST/DELETE /api/admin/proxies.
+func (s *Server) handleProxies(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.checker.ListProxies())
+
+	case http.MethodPost:
+		var proxy types.Proxy
+		if err := json.NewDecoder(r.Body).Decode(&proxy); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if err := s.checker.AddProxy(proxy); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+
+	case http.MethodDelete:
+		var target struct {
+			Host string `json:"host"`
+			Port int    `json:"port"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if err := s.checker.RemoveProxy(target.Host, target.Port); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "expected GET, POST, or DELETE")
+	}
+}
+
+// handleProxyHealth handles GET /api/admin/proxies/health, returning the
+// active/passive health table so operators can see which proxies are
+// bleeding without cross-referencing logs.
+func (s *Server) handleProxyHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "expected GET")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.checker.ProxyHealth())
+}
+
+// handleConfigsReload handles POST /api/admin/configs/reload, re-parsing
+// either the request body's "paths" or the server's configured
+// Config.ConfigPaths, and validates before swapping.
+func (s *Server) handleConfigsReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "expected POST")
+		return
+	}
+
+	paths := s.config.ConfigPaths
+	var body struct {
+		Paths []string `json:"paths"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if len(body.Paths) > 0 {
+			paths = body.Paths
+		}
+	}
+
+	if err := s.checker.ReloadConfigs(paths); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleWorkersScale handles POST /api/admin/workers/scale with body
+// {"delta": N} - positive to grow the pool, negative to shrink it.
+func (s *Server) handleWorkersScale(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "expected POST")
+		return
+	}
+
+	var body struct {
+		Delta int `json:"delta"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if err := s.checker.ScaleWorkers(body.Delta); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -7,23 +7,56 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"math/rand"
+	"net"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/net/publicsuffix"
+
+	"universal-checker/internal/adminapi"
 	"universal-checker/internal/config"
 	"universal-checker/internal/logger"
 	"universal-checker/internal/proxy"
+	"universal-checker/internal/workers"
 	"universal-checker/pkg/httpclient"
+	"universal-checker/pkg/proxypolicy"
+	"universal-checker/pkg/streamserver"
 	"universal-checker/pkg/types"
 	"universal-checker/pkg/utils"
 )
 
+// Pool names registered in Checker.pools, and accepted by Resize. Proxy
+// scraping and proxy validation aren't in this set: scraping is a one-shot
+// proxy.NewScraper pass with nothing to resize, and validation already runs
+// its own concurrency inside proxypolicy.HealthChecker (see
+// Checker.proxyHealthChecker) rather than a persistent worker loop.
+const (
+	PoolComboCheck   = "combo_check"
+	PoolResultWriter = "result_writer"
+)
+
+// statsStreamBuffer is the StatsStream channel's capacity. It only ever
+// needs to hold one pending snapshot: publishStatsChange coalesces by
+// replacing whatever's already buffered instead of queuing, so a slow
+// consumer sees the latest state rather than a backlog.
+const statsStreamBuffer = 1
+
+// facilityHTTPTrace gates logDetailedRequest/logDetailedResponse, whose
+// body-buffering and restoring is expensive enough per-request that it
+// shouldn't run unless someone's actually watching for it.
+const facilityHTTPTrace = "http_trace"
+
+func init() {
+	logger.RegisterFacility(facilityHTTPTrace, "Full request/response logging, including headers, cookies, and bodies")
+}
+
 // ============================================================================
 // TYPES AND STRUCTURES
 // ============================================================================
@@ -31,16 +64,18 @@ import (
 // Checker represents the main checker engine with thread-safe concurrent operations
 //
 // CONCURRENCY CONTRACT:
-// - Goroutine Coordination: All goroutines (workers, result processor, task generator)
-//   are tracked via sync.WaitGroup ensuring graceful shutdown
+// - Goroutine Coordination: the combo-check and result-writer worker groups
+//   are each a workers.Pool (comboPool, resultPool) with their own internal
+//   WaitGroup; auxiliary goroutines (task generator, admin reloader) are
+//   tracked via wg. Both mechanisms ensure graceful shutdown.
 // - Context Cancellation: All goroutines respect ctx.Done() for immediate cancellation
 // - Statistics: Protected by statsMutex (RWMutex) - concurrent reads, exclusive writes
-// - Proxy Rotation: Protected by proxyMutex (Mutex) - exclusive access to proxyIndex
+// - Proxy Rotation: Protected by proxyMutex (Mutex) - exclusive access when snapshotting Proxies
 // - Channel Safety: taskChan and resultChan use buffered channels with context-aware operations
 //
 // THREAD-SAFETY GUARANTEES:
 // - Stats.* fields: MUST acquire statsMutex before read/write
-// - proxyIndex: MUST acquire proxyMutex before read/write
+// - Proxies: MUST acquire proxyMutex before read/write
 // - Channels: Thread-safe by Go runtime, context-aware sends prevent deadlocks
 // - All other fields: Read-only after initialization (safe for concurrent access)
 type Checker struct {
@@ -57,14 +92,18 @@ type Checker struct {
 	// Worker management and coordination
 	ctx        context.Context     // Cancellation signal for all goroutines
 	cancel     context.CancelFunc  // Trigger for graceful shutdown
-	wg         sync.WaitGroup      // Tracks all spawned goroutines (workers + auxiliaries)
+	wg         sync.WaitGroup      // Tracks auxiliary goroutines (task generator, admin reloader); comboPool/resultPool track their own workers
 	
 	// Statistics tracking (protected by statsMutex)
 	statsMutex sync.RWMutex        // RWMutex: concurrent reads, exclusive writes
+
+	// statsStream backs StatsStream(): a single-slot, coalescing channel a
+	// front-end can subscribe to instead of polling GetStats on a ticker.
+	// publishStatsChange never blocks on it - see that method.
+	statsStream chan types.CheckerStats
 	
 	// Proxy rotation (protected by proxyMutex)
-	proxyIndex int                 // Current proxy index (protected by proxyMutex)
-	proxyMutex sync.Mutex          // Mutex: exclusive access to proxyIndex
+	proxyMutex sync.Mutex          // Mutex: exclusive access when snapshotting Proxies for selection
 	
 	// Result exporter
 	exporter   *ResultExporter
@@ -76,9 +115,67 @@ type Checker struct {
 	// Advanced proxy management systems
 	proxyManager    *AdvancedProxyManager
 	healthMonitor   *ProxyHealthMonitor
-	
+
+	// Pluggable proxy selection: used by getNextProxy's fallback path when
+	// proxyManager.GetBestProxy has nothing to offer. proxyStats feeds the
+	// weighted/least-conn policies real in-flight/latency/success signal;
+	// proxyPolicies caches one resolved policy instance per config so
+	// stateful policies (round robin's index, ...) persist across calls.
+	proxyStats    *proxypolicy.Stats
+	proxyPolicies *proxypolicy.Registry
+
+	// proxyHealthChecker actively probes every proxy on a configurable
+	// interval and folds in passive transport failures reported by workers,
+	// feeding getWorkingProxies, getNextHealthyProxy, and getNextProxy's
+	// policy fallback whether a proxy is currently eligible for use. Nil
+	// (every check a no-op, defaulting to healthy) unless
+	// CheckerConfig.ProxyHealthCheckURL is set.
+	proxyHealthChecker *proxypolicy.HealthChecker
+
 	// Logging and reporting
 	logger          *logger.StructuredLogger
+
+	// Live observability: streams results/stats/logs over WebSocket so
+	// dashboards and remote TUIs can watch a run in flight. Nil (and every
+	// call site a no-op) unless CheckerConfig.StreamServerAddr is set.
+	streamServer *streamserver.Server
+
+	// Admin control: config swaps, proxy add/remove, config reload and
+	// worker scaling are serialized through adminCmds so generateTasks and
+	// worker always observe a consistent Config/Configs/Proxies snapshot
+	// instead of racing a partial update. adminMutex guards the fields those
+	// commands mutate.
+	adminMutex sync.RWMutex
+	adminCmds  chan adminCommand
+	adminServer *adminapi.Server
+
+	// debugServer exposes GET/POST /debug/facilities and GET /log for
+	// runtime debug-facility toggling and log tailing. Nil unless
+	// CheckerConfig.DebugAPIAddr is set and the logger was configured with
+	// LoggerConfig.RemoteControl.
+	debugServer *debugAPIServer
+
+	// comboPool and resultPool run the checker's two persistent worker
+	// loops - combo checking off taskChan, and result handling off
+	// resultChan - as workers.Pool instances, so both share one
+	// resize/metrics/panic-recovery implementation instead of the
+	// hand-rolled bookkeeping this used to be. pools indexes both by name
+	// for Resize(poolName, n) and GetStats' per-pool breakdown.
+	comboPool  *workers.Pool
+	resultPool *workers.Pool
+	pools      map[string]*workers.Pool
+
+	// Chaos/fault-injection testing: nil (and createHTTPClient's wrapping a
+	// no-op) unless CheckerConfig.ChaosMode is set.
+	chaosEngine *httpclient.ChaosPolicyEngine
+	chaosServer *httpclient.ChaosPolicyServer
+}
+
+// adminCommand is a single serialized mutation request, run on the admin
+// reload goroutine and reported back to the (blocked) caller via done.
+type adminCommand struct {
+	run  func() error
+	done chan error
 }
 
 // ============================================================================
@@ -104,16 +201,21 @@ func initializeProxySystem() (*AdvancedProxyManager, *ProxyHealthMonitor) {
 	return proxyManager, healthMonitor
 }
 
-// initializeLogger creates a structured logger with fallback to stdout on file error
-func initializeLogger() (*logger.StructuredLogger, error) {
+// initializeLogger creates a structured logger with fallback to stdout on
+// file error. Additional destinations - a human console sink, a rotating
+// file, a webhook that only fires on ERROR, and so on - come from
+// config.LogSinks rather than being hard-coded here.
+func initializeLogger(config *types.CheckerConfig) (*logger.StructuredLogger, error) {
 	loggerConfig := logger.LoggerConfig{
-		Level:      logger.INFO,
-		JSONFormat: true,
-		OutputFile: "logs/checker.log",
-		BufferSize: 1000,
-		Component:  "checker",
+		Level:         logger.INFO,
+		JSONFormat:    true,
+		OutputFile:    "logs/checker.log",
+		BufferSize:    1000,
+		Component:     "checker",
+		Sinks:         config.LogSinks,
+		RemoteControl: config.RemoteControl,
 	}
-	
+
 	structuredLogger, err := logger.NewStructuredLogger(loggerConfig)
 	if err != nil {
 		// Fall back to stdout if file logging fails
@@ -128,6 +230,105 @@ func initializeLogger() (*logger.StructuredLogger, error) {
 	return structuredLogger, nil
 }
 
+// initializeStreamServer creates the WebSocket stream server when
+// config.StreamServerAddr is set, or returns nil to leave live streaming
+// disabled entirely.
+func initializeStreamServer(config *types.CheckerConfig) *streamserver.Server {
+	if config.StreamServerAddr == "" {
+		return nil
+	}
+
+	return streamserver.NewServer(streamserver.Config{
+		Addr:            config.StreamServerAddr,
+		MaxMessageBytes: config.StreamServerMaxMessageBytes,
+	})
+}
+
+// initializeAdminServer creates the admin control API when
+// config.AdminAPIAddr is set, or returns nil to leave it disabled.
+func initializeAdminServer(config *types.CheckerConfig, api adminapi.CheckerAPI) *adminapi.Server {
+	if config.AdminAPIAddr == "" {
+		return nil
+	}
+
+	return adminapi.NewServer(adminapi.Config{
+		Addr:        config.AdminAPIAddr,
+		AuthToken:   config.AdminAPIToken,
+		ConfigPaths: config.ConfigPaths,
+	}, api)
+}
+
+// initializeDebugServer creates the debug REST API when config.DebugAPIAddr
+// is set and the logger was opted into remote access via
+// LoggerConfig.RemoteControl, or returns nil to leave it disabled. A
+// DebugAPIAddr with a non-remote-control logger is treated as
+// misconfiguration rather than silently doing nothing dangerous - the
+// caller logs a warning in that case.
+func initializeDebugServer(config *types.CheckerConfig, l *logger.StructuredLogger, checker *Checker) *debugAPIServer {
+	if config.DebugAPIAddr == "" || !l.RemoteControlEnabled() {
+		return nil
+	}
+	return newDebugAPIServer(config.DebugAPIAddr, checker)
+}
+
+// initializeChaosEngine creates the chaos/fault-injection policy engine when
+// config.ChaosMode is enabled, loading config.ChaosPolicyPath if one is set.
+// Returns nil to leave chaos mode disabled entirely.
+func initializeChaosEngine(config *types.CheckerConfig) (*httpclient.ChaosPolicyEngine, error) {
+	if !config.ChaosMode {
+		return nil, nil
+	}
+
+	engine := httpclient.NewChaosPolicyEngine()
+	if config.ChaosPolicyPath != "" {
+		if err := engine.LoadPolicyFile(config.ChaosPolicyPath); err != nil {
+			return nil, err
+		}
+	}
+	return engine, nil
+}
+
+// initializeProxyHealthChecker creates the active/passive proxy health
+// checker when config.ProxyHealthCheckURL is set, or returns nil to leave
+// every proxy assumed healthy - the pre-health-checker behavior.
+func initializeProxyHealthChecker(config *types.CheckerConfig) *proxypolicy.HealthChecker {
+	if config.ProxyHealthCheckURL == "" {
+		return nil
+	}
+
+	probe := proxypolicy.DefaultProbeConfig()
+	probe.URL = config.ProxyHealthCheckURL
+	if config.ProxyHealthCheckInterval > 0 {
+		probe.Interval = config.ProxyHealthCheckInterval
+	}
+	if config.ProxyHealthCheckTimeout > 0 {
+		probe.Timeout = config.ProxyHealthCheckTimeout
+	}
+	if config.ProxyHealthExpectedStatusMin > 0 {
+		probe.MinStatus = config.ProxyHealthExpectedStatusMin
+	}
+	if config.ProxyHealthExpectedStatusMax > 0 {
+		probe.MaxStatus = config.ProxyHealthExpectedStatusMax
+	}
+	probe.BodySubstring = config.ProxyHealthExpectedBodySubstring
+	if config.ProxyHealthExpectedBodyPattern != "" {
+		pattern, err := regexp.Compile(config.ProxyHealthExpectedBodyPattern)
+		if err != nil {
+			log.Printf("[WARN] Invalid proxy health-check body pattern %q, ignoring: %v", config.ProxyHealthExpectedBodyPattern, err)
+		} else {
+			probe.BodyPattern = pattern
+		}
+	}
+	if config.ProxyHealthEjectAfterFailures > 0 {
+		probe.EjectAfterFailures = config.ProxyHealthEjectAfterFailures
+	}
+	if config.ProxyHealthCooldown > 0 {
+		probe.Cooldown = config.ProxyHealthCooldown
+	}
+
+	return proxypolicy.NewHealthChecker(probe)
+}
+
 // initializeChannels creates task and result channels based on worker configuration
 func initializeChannels(config *types.CheckerConfig) (chan types.WorkerTask, chan types.WorkerResult) {
 	channelSize := config.MaxWorkers * 2
@@ -146,9 +347,17 @@ func NewChecker(config *types.CheckerConfig) *Checker {
 	
 	// Initialize proxy management subsystem
 	proxyManager, healthMonitor := initializeProxySystem()
-	
+
+	// Initialize the pluggable proxy selection policies used as getNextProxy's
+	// fallback when proxyManager has no opinion yet
+	proxyStats := proxypolicy.NewStats()
+	proxyPolicies := proxypolicy.NewRegistry(proxyStats)
+
+	// Initialize the active/passive proxy health checker, if configured
+	proxyHealthChecker := initializeProxyHealthChecker(config)
+
 	// Initialize logging subsystem with error handling
-	structuredLogger, err := initializeLogger()
+	structuredLogger, err := initializeLogger(config)
 	if err != nil {
 		// Logger initialization failed completely (both file and stdout)
 		// This is extremely rare but we handle it gracefully
@@ -160,9 +369,26 @@ func NewChecker(config *types.CheckerConfig) *Checker {
 	
 	// Initialize communication channels
 	taskChan, resultChan := initializeChannels(config)
-	
+
+	// Initialize the optional live-streaming subsystem
+	streamServer := initializeStreamServer(config)
+	if streamServer != nil {
+		structuredLogger.RegisterExporter(streamServer.LogSink())
+	}
+
+	// Initialize the optional chaos/fault-injection subsystem
+	chaosEngine, err := initializeChaosEngine(config)
+	if err != nil {
+		log.Printf("[WARN] Failed to load chaos policy file, chaos mode disabled: %v", err)
+		chaosEngine = nil
+	}
+	var chaosServer *httpclient.ChaosPolicyServer
+	if chaosEngine != nil && config.ChaosControlAddr != "" {
+		chaosServer = httpclient.NewChaosPolicyServer(config.ChaosControlAddr, chaosEngine)
+	}
+
 	// Assemble the checker with all initialized subsystems
-	return &Checker{
+	c := &Checker{
 		Config:         config,
 		Stats:          &types.CheckerStats{},
 		Proxies:        make([]types.Proxy, 0),
@@ -177,8 +403,50 @@ func NewChecker(config *types.CheckerConfig) *Checker {
 		varManipulator: varManipulator,
 		proxyManager:   proxyManager,
 		healthMonitor:  healthMonitor,
+		proxyStats:     proxyStats,
+		proxyPolicies:  proxyPolicies,
+		proxyHealthChecker: proxyHealthChecker,
 		logger:         structuredLogger,
+		streamServer:   streamServer,
+		adminCmds:      make(chan adminCommand),
+		chaosEngine:    chaosEngine,
+		chaosServer:    chaosServer,
+		statsStream:    make(chan types.CheckerStats, statsStreamBuffer),
+	}
+
+	// comboPool and resultPool close over c.taskChan/c.resultChan and
+	// c.worker/c.processResults, so - like the admin/debug servers below -
+	// they're wired up once c itself exists rather than in the struct
+	// literal above.
+	c.comboPool = workers.NewPool(workers.Config{
+		Name:           PoolComboCheck,
+		Size:           config.MaxWorkers,
+		PanicPolicy:    workers.PanicPolicyRequeue,
+		QueueDepthFunc: func() int { return len(c.taskChan) },
+	}, c.worker, structuredLogger)
+	c.resultPool = workers.NewPool(workers.Config{
+		Name:           PoolResultWriter,
+		Size:           1,
+		PanicPolicy:    workers.PanicPolicyRequeue,
+		QueueDepthFunc: func() int { return len(c.resultChan) },
+	}, func(ctx context.Context) { c.processResults() }, structuredLogger)
+	c.pools = map[string]*workers.Pool{
+		PoolComboCheck:   c.comboPool,
+		PoolResultWriter: c.resultPool,
 	}
+
+	// The admin API depends on c satisfying adminapi.CheckerAPI, so it's
+	// wired up last, once c itself exists.
+	c.adminServer = initializeAdminServer(config, c)
+
+	// Same reasoning as the admin API: the debug server reads back through
+	// c.logger, so it's wired up once c exists too.
+	c.debugServer = initializeDebugServer(config, structuredLogger, c)
+	if config.DebugAPIAddr != "" && c.debugServer == nil {
+		log.Printf("[WARN] CheckerConfig.DebugAPIAddr is set but the logger wasn't configured with RemoteControl - debug API disabled")
+	}
+
+	return c
 }
 
 // LoadConfigs loads configuration files
@@ -243,21 +511,16 @@ func (c *Checker) LoadProxies(proxyPath string) error {
 		}
 		defer file.Close()
 
-		scanner := bufio.NewScanner(file)
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if line == "" {
-				continue
-			}
-
-			proxy := c.parseProxy(line)
-			if proxy != nil {
-				// Add to advanced proxy manager
-				if err := c.proxyManager.AddProxy(*proxy); err != nil {
-					log.Printf("[WARN] Failed to add proxy %s:%d: %v", proxy.Host, proxy.Port, err)
-				} else {
-					c.Proxies = append(c.Proxies, *proxy)
-				}
+		proxies, err := c.parseProxyList(file)
+		if err != nil {
+			return err
+		}
+		for _, proxy := range proxies {
+			// Add to advanced proxy manager
+			if err := c.proxyManager.AddProxy(proxy); err != nil {
+				log.Printf("[WARN] Failed to add proxy %s:%d: %v", proxy.Host, proxy.Port, err)
+			} else {
+				c.Proxies = append(c.Proxies, proxy)
 			}
 		}
 	}
@@ -274,7 +537,7 @@ func (c *Checker) LoadProxies(proxyPath string) error {
 func (c *Checker) Start() error {
 	c.Stats.StartTime = time.Now()
 	
-	c.logger.Info("Starting checker", map[string]interface{}{
+	c.logger.Info(c.ctx, "Starting checker", map[string]interface{}{
 		"max_workers": c.Config.MaxWorkers,
 		"total_combos": len(c.Combos),
 		"total_configs": len(c.Configs),
@@ -283,29 +546,92 @@ func (c *Checker) Start() error {
 	
 	// Start health monitor for proxy management
 	c.healthMonitor.Start()
-	
+
+	// Start the active proxy health checker, if configured
+	if c.proxyHealthChecker != nil {
+		c.proxyHealthChecker.Start(c.snapshotProxies)
+	}
+
 	// Start worker subsystems with lifecycle tracking
 	c.startWorkerPool()
 	c.startResultProcessor()
 	c.startTaskGenerator()
+	c.startAdminReloader()
 
-	c.logger.Info("Checker started successfully")
+	if err := c.startStreamServer(); err != nil {
+		// Live streaming is a convenience, not a correctness requirement -
+		// log and keep the checker running headless over WebSocket.
+		c.logger.Warn(c.ctx, "Failed to start stream server", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	if err := c.startAdminServer(); err != nil {
+		// Same reasoning as the stream server: hot-reload is a convenience,
+		// so a bind failure shouldn't stop the run.
+		c.logger.Warn(c.ctx, "Failed to start admin API", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	if c.chaosServer != nil {
+		if err := c.chaosServer.Start(); err != nil {
+			// Chaos mode still applies via the loaded policy file even if the
+			// control endpoint can't bind - only live tuning is lost.
+			c.logger.Warn(c.ctx, "Failed to start chaos policy server", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	if c.debugServer != nil {
+		if err := c.debugServer.Start(); err != nil {
+			// Same reasoning as the stream/admin servers: debugging is a
+			// convenience, not a correctness requirement.
+			c.logger.Warn(c.ctx, "Failed to start debug API", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	c.logger.Info(c.ctx, "Checker started successfully")
 	return nil
 }
 
 // Stop stops the checking process with coordinated shutdown sequence
 func (c *Checker) Stop() {
-	c.logger.Info("Stopping checker")
+	c.logger.Info(c.ctx, "Stopping checker")
 	
 	// Stop external subsystems first
 	c.healthMonitor.Stop()
-	
+
+	if c.proxyHealthChecker != nil {
+		c.proxyHealthChecker.Stop()
+	}
+
 	// Execute worker pool shutdown sequence
 	c.stopWorkerPool()
-	
+
+	// Stop live streaming last so it can broadcast the tail of the run
+	if c.streamServer != nil {
+		c.streamServer.Stop()
+	}
+
+	if c.adminServer != nil {
+		c.adminServer.Stop()
+	}
+
+	if c.chaosServer != nil {
+		c.chaosServer.Stop()
+	}
+
+	if c.debugServer != nil {
+		c.debugServer.Stop()
+	}
+
 	// Log final statistics
 	stats := c.GetStats()
-	c.logger.Info("Checker stopped", map[string]interface{}{
+	c.logger.Info(c.ctx, "Checker stopped", map[string]interface{}{
 		"total_processed": stats.ValidCombos + stats.InvalidCombos + stats.ErrorCombos,
 		"valid_combos": stats.ValidCombos,
 		"invalid_combos": stats.InvalidCombos,
@@ -322,21 +648,53 @@ func (c *Checker) Stop() {
 // WORKER MANAGEMENT
 // ============================================================================
 
-// startWorkerPool spawns N worker goroutines with proper lifecycle tracking
+// startWorkerPool starts the combo-check pool with its configured size.
 func (c *Checker) startWorkerPool() {
-	for i := 0; i < c.Config.MaxWorkers; i++ {
-		c.wg.Add(1)
-		go c.worker()
+	c.comboPool.Start(c.ctx)
+}
+
+// ScaleWorkers grows or shrinks the combo-check pool by delta through the
+// serialized admin reload goroutine, so a resize never races generateTasks
+// handing out tasks to a worker that's mid-cancellation. It's the delta-based
+// counterpart that predates Resize - kept for adminapi.CheckerAPI and the existing
+// POST /api/admin/workers/scale body - and always targets PoolComboCheck.
+func (c *Checker) ScaleWorkers(delta int) error {
+	err := c.submitAdminCommand(func() error {
+		c.comboPool.Resize(delta)
+		return nil
+	})
+	if err == nil {
+		c.publishStatsChange(c.GetStats())
+	}
+	return err
+}
+
+// Resize sets the named pool's worker count to n, growing or shrinking it
+// live through the same serialized admin reload goroutine ScaleWorkers uses.
+// It backs the GUI's Workers entry, which can retune pool sizes mid-run
+// without stopping the checker.
+func (c *Checker) Resize(poolName string, n int) error {
+	if n < 0 {
+		return fmt.Errorf("pool size must be non-negative, got %d", n)
+	}
+	pool, ok := c.pools[poolName]
+	if !ok {
+		return fmt.Errorf("unknown worker pool %q", poolName)
 	}
+
+	err := c.submitAdminCommand(func() error {
+		pool.Resize(n - pool.Size())
+		return nil
+	})
+	if err == nil {
+		c.publishStatsChange(c.GetStats())
+	}
+	return err
 }
 
-// startResultProcessor spawns the result processing goroutine with WaitGroup tracking
+// startResultProcessor starts the result-writer pool with its configured size.
 func (c *Checker) startResultProcessor() {
-	c.wg.Add(1)
-	go func() {
-		defer c.wg.Done()
-		c.processResults()
-	}()
+	c.resultPool.Start(c.ctx)
 }
 
 // startTaskGenerator spawns the task generation goroutine with WaitGroup tracking
@@ -348,25 +706,47 @@ func (c *Checker) startTaskGenerator() {
 	}()
 }
 
-// stopWorkerPool initiates worker pool shutdown sequence with proper ordering
+// startStreamServer starts the WebSocket stream server, if configured.
+// Stats reach its /ws/stats subscribers straight from publishStatsChange,
+// the same call site that feeds StatsStream, rather than a separate
+// polling goroutine.
+func (c *Checker) startStreamServer() error {
+	if c.streamServer == nil {
+		return nil
+	}
+
+	return c.streamServer.Start()
+}
+
+// stopWorkerPool initiates worker pool shutdown sequence with proper
+// ordering: cancelling ctx makes the combo-check workers exit (tasks still
+// queued in taskChan are not worked through), and comboPool.Stop waits for
+// them before resultChan is closed behind them, so the result-writer pool
+// never reads from a channel a still-running combo worker might still
+// write to.
 func (c *Checker) stopWorkerPool() {
 	// Signal cancellation to all goroutines
 	c.cancel()
-	
-	// Close task channel to signal workers to exit
+
+	// Close task channel and wait for the combo-check pool to exit
 	close(c.taskChan)
-	
-	// Wait for all workers and auxiliary goroutines to complete
+	c.comboPool.Stop()
+
+	// Wait for auxiliary goroutines (task generator, admin reloader) to
+	// observe cancellation
 	c.wg.Wait()
-	
-	// Close result channel after all workers finished
+
+	// Close result channel now that nothing can send to it, then let the
+	// result-writer pool drain and exit
 	close(c.resultChan)
+	c.resultPool.Stop()
 }
 
-// receiveTask receives a task from the task channel with context and close handling
-func (c *Checker) receiveTask() (types.WorkerTask, bool) {
+// receiveTask receives a task from the task channel, exiting on either the
+// worker's own ctx (cancelled individually by Pool.Resize) or channel close.
+func (c *Checker) receiveTask(ctx context.Context) (types.WorkerTask, bool) {
 	select {
-	case <-c.ctx.Done():
+	case <-ctx.Done():
 		return types.WorkerTask{}, false
 	case task, ok := <-c.taskChan:
 		return task, ok
@@ -378,12 +758,13 @@ func (c *Checker) sendResult(result types.WorkerResult) {
 	c.resultChan <- result
 }
 
-// worker is the main worker function that processes tasks
-func (c *Checker) worker() {
-	defer c.wg.Done()
-
+// worker is the main worker function that processes tasks. ctx is this
+// worker's own cancellation signal - a child of c.ctx that comboPool.Resize
+// can cancel independently to shrink the pool. It's a workers.WorkFunc, run
+// by c.comboPool.
+func (c *Checker) worker(ctx context.Context) {
 	for {
-		task, ok := c.receiveTask()
+		task, ok := c.receiveTask(ctx)
 		if !ok {
 			return // Channel closed or context cancelled
 		}
@@ -394,29 +775,44 @@ func (c *Checker) worker() {
 }
 
 // selectProxyForConfig selects appropriate proxy based on config requirements
-func (c *Checker) selectProxyForConfig(config types.Config) *types.Proxy {
+func (c *Checker) selectProxyForConfig(combo types.Combo, config types.Config) *types.Proxy {
 	if config.RequiresProxy {
-		return c.getNextHealthyProxy()
+		return c.getNextHealthyProxy(combo, config)
 	} else if config.UseProxy {
-		return c.getNextProxy()
+		return c.getNextProxy(combo, config)
 	}
 	return nil
 }
 
-// createTask creates a worker task with appropriate proxy selection
+// createTask creates a worker task with appropriate proxy selection. It also
+// mints the task's correlation_id/task_id and attaches them - along with
+// config_name, combo_user, and proxy_host - as ambient logging fields on
+// task.Ctx, so checkCombo and everything downstream of it log with full
+// context via logger.FromContext instead of repeating these IDs by hand.
 func (c *Checker) createTask(combo types.Combo, config types.Config) (types.WorkerTask, bool) {
 	// Skip if config requires proxy but none available
 	if c.shouldSkipTaskDueToProxy(config) {
 		return types.WorkerTask{}, false
 	}
-	
-	proxy := c.selectProxyForConfig(config)
+
+	proxy := c.selectProxyForConfig(combo, config)
 	if config.RequiresProxy && proxy == nil {
-		c.logger.Warn(fmt.Sprintf("No proxy available for required proxy config %s", config.Name), nil)
+		c.logger.Warn(c.ctx, fmt.Sprintf("No proxy available for required proxy config %s", config.Name), nil)
 		return types.WorkerTask{}, false
 	}
-	
+
+	fields := map[string]interface{}{
+		"correlation_id": utils.GenerateCorrelationID(),
+		"task_id":        utils.GenerateTaskID("check"),
+		"config_name":    config.Name,
+		"combo_user":     combo.Username,
+	}
+	if proxy != nil {
+		fields["proxy_host"] = proxy.Host
+	}
+
 	task := types.WorkerTask{
+		Ctx:    logger.WithFields(c.ctx, fields),
 		Combo:  combo,
 		Config: config,
 		Proxy:  proxy,
@@ -434,10 +830,12 @@ func (c *Checker) sendTaskWithContext(task types.WorkerTask) bool {
 	}
 }
 
-// generateTasks generates tasks for all combo/config combinations
+// generateTasks generates tasks for all combo/config combinations. It
+// snapshots Configs rather than ranging over c.Configs directly so a
+// concurrent ReloadConfigs (via the admin API) can't be observed mid-swap.
 func (c *Checker) generateTasks() {
 	for _, combo := range c.Combos {
-		for _, config := range c.Configs {
+		for _, config := range c.snapshotConfigs() {
 			task, ok := c.createTask(combo, config)
 			if !ok {
 				continue
@@ -450,6 +848,178 @@ func (c *Checker) generateTasks() {
 	}
 }
 
+// ============================================================================
+// ADMIN CONTROL
+// ============================================================================
+
+// startAdminReloader spawns the goroutine that serializes every admin
+// mutation (config swap, proxy add/remove, config reload, worker scale) so
+// generateTasks and worker never observe a partially applied update.
+func (c *Checker) startAdminReloader() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.runAdminReloader()
+	}()
+}
+
+// runAdminReloader executes queued admin commands one at a time until the
+// checker is cancelled.
+func (c *Checker) runAdminReloader() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case cmd := <-c.adminCmds:
+			cmd.done <- cmd.run()
+		}
+	}
+}
+
+// submitAdminCommand enqueues fn to run on the admin reload goroutine and
+// blocks until it completes, giving adminapi's HTTP handlers a synchronous
+// result while keeping every mutation race-free against generateTasks.
+func (c *Checker) submitAdminCommand(fn func() error) error {
+	done := make(chan error, 1)
+
+	select {
+	case c.adminCmds <- adminCommand{run: fn, done: done}:
+	case <-c.ctx.Done():
+		return fmt.Errorf("checker: stopped")
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-c.ctx.Done():
+		return fmt.Errorf("checker: stopped")
+	}
+}
+
+// startAdminServer starts the admin control API, if configured.
+func (c *Checker) startAdminServer() error {
+	if c.adminServer == nil {
+		return nil
+	}
+	return c.adminServer.Start()
+}
+
+// snapshotConfigs returns a copy of c.Configs, guarding readers against a
+// concurrent ReloadConfigs swap.
+func (c *Checker) snapshotConfigs() []types.Config {
+	c.adminMutex.RLock()
+	defer c.adminMutex.RUnlock()
+
+	configs := make([]types.Config, len(c.Configs))
+	copy(configs, c.Configs)
+	return configs
+}
+
+// UpdateConfig atomically applies a partial CheckerConfig update (retry
+// count, timeouts, save-valid-only, ...) through the serialized admin
+// reload goroutine. Unset fields in patch are left untouched.
+func (c *Checker) UpdateConfig(patch types.CheckerConfigPatch) error {
+	return c.submitAdminCommand(func() error {
+		c.adminMutex.Lock()
+		defer c.adminMutex.Unlock()
+
+		if patch.RetryCount != nil {
+			c.Config.RetryCount = *patch.RetryCount
+		}
+		if patch.RequestTimeout != nil {
+			c.Config.RequestTimeout = *patch.RequestTimeout
+		}
+		if patch.SaveValidOnly != nil {
+			c.Config.SaveValidOnly = *patch.SaveValidOnly
+		}
+		if patch.ProxyRotation != nil {
+			c.Config.ProxyRotation = *patch.ProxyRotation
+		}
+		return nil
+	})
+}
+
+// ListProxies returns a copy of the current proxy list, safe to call
+// concurrently with AddProxy/RemoveProxy.
+func (c *Checker) ListProxies() []types.Proxy {
+	c.adminMutex.RLock()
+	defer c.adminMutex.RUnlock()
+
+	proxies := make([]types.Proxy, len(c.Proxies))
+	copy(proxies, c.Proxies)
+	return proxies
+}
+
+// AddProxy adds a proxy at runtime through the serialized admin reload
+// goroutine, threading it through proxyManager the same way LoadProxies
+// does at startup.
+func (c *Checker) AddProxy(proxy types.Proxy) error {
+	return c.submitAdminCommand(func() error {
+		if err := c.proxyManager.AddProxy(proxy); err != nil {
+			return err
+		}
+
+		c.adminMutex.Lock()
+		c.proxyMutex.Lock()
+		c.Proxies = append(c.Proxies, proxy)
+		c.Stats.TotalProxies = len(c.Proxies)
+		c.proxyMutex.Unlock()
+		c.adminMutex.Unlock()
+		return nil
+	})
+}
+
+// RemoveProxy removes a proxy by host:port at runtime through the
+// serialized admin reload goroutine.
+func (c *Checker) RemoveProxy(host string, port int) error {
+	return c.submitAdminCommand(func() error {
+		if err := c.proxyManager.RemoveProxy(host, port); err != nil {
+			return err
+		}
+
+		c.adminMutex.Lock()
+		defer c.adminMutex.Unlock()
+		c.proxyMutex.Lock()
+		defer c.proxyMutex.Unlock()
+
+		for i, p := range c.Proxies {
+			if p.Host == host && p.Port == port {
+				c.Proxies = append(c.Proxies[:i], c.Proxies[i+1:]...)
+				break
+			}
+		}
+		c.Stats.TotalProxies = len(c.Proxies)
+		return nil
+	})
+}
+
+// ReloadConfigs re-parses configPaths and, only if every one of them parses
+// successfully, atomically swaps the result in for c.Configs. A parse
+// failure leaves the existing Configs untouched (an implicit rollback).
+func (c *Checker) ReloadConfigs(configPaths []string) error {
+	return c.submitAdminCommand(func() error {
+		parser := config.NewParser()
+		reloaded := make([]types.Config, 0, len(configPaths))
+
+		for _, path := range configPaths {
+			cfg, err := parser.ParseConfig(path)
+			if err != nil {
+				return fmt.Errorf("failed to parse config %s: %v", path, err)
+			}
+			reloaded = append(reloaded, *cfg)
+		}
+
+		if len(reloaded) == 0 {
+			return fmt.Errorf("reload produced no valid configs, keeping existing %d", len(c.Configs))
+		}
+
+		c.adminMutex.Lock()
+		c.Configs = reloaded
+		c.adminMutex.Unlock()
+		return nil
+	})
+}
+
 // ============================================================================
 // COMBO CHECKING
 // ============================================================================
@@ -457,83 +1027,140 @@ func (c *Checker) generateTasks() {
 // checkCombo checks a single combo against a config with comprehensive logging
 func (c *Checker) checkCombo(task types.WorkerTask) types.WorkerResult {
 	start := time.Now()
-	correlationID := utils.GenerateCorrelationID()
-	taskID := utils.GenerateTaskID("check")
-	
-	// Log task start
-	c.logger.LogTaskStart(taskID, "combo_check", correlationID)
-	
-	// Create HTTP client with timeout context
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+
+	// task.Ctx already carries correlation_id/task_id/config_name/combo_user
+	// (and proxy_host, if a proxy was assigned) from createTask; every log
+	// line below rides on it via logger.FromContext instead of repeating
+	// those IDs as explicit arguments.
+	taskCtx := task.Ctx
+	if taskCtx == nil {
+		taskCtx = c.ctx
+	}
+	taskCtx, correlationID := logger.EnsureCorrelationID(taskCtx)
+	L := logger.FromContext(taskCtx)
+
+	L.Info(taskCtx, "Task started: combo_check")
+
+	// Derive the HTTP timeout context from taskCtx so cancellation and the
+	// ambient logging fields travel together.
+	ctx, cancel := context.WithTimeout(taskCtx, 30*time.Second)
 	defer cancel()
-	
-retryCount := 0
+
+	retryCount := 0
 	var resp *http.Response
 	var req *http.Request
 	var err error
-	
+
 	// Set default retry count if not configured
 	retryLimit := c.Config.RetryCount
 	if retryLimit == 0 {
 		retryLimit = 3 // Default to 3 retries
 	}
-	
+
+	// One cookie jar per combo, shared across every retry/proxy swap below so
+	// a CSRF token -> login -> 2FA redirect chain survives a retry instead of
+	// starting over cookie-less. It's a local variable that goes out of scope
+	// when checkCombo returns, so nothing on Checker keeps it alive past this
+	// combo - the next combo (even for the same config) starts with a fresh,
+	// empty jar rather than inheriting the last account's session.
+	jar, err := newComboCookieJar()
+	if err != nil {
+		L.Warn(taskCtx, "Failed to create combo cookie jar, proceeding without cookie persistence", map[string]interface{}{
+			"error": err.Error(),
+		})
+		jar = nil
+	}
+	seededJar := false
+
 	for retryCount < retryLimit {
-		client := c.createHTTPClient(task.Proxy)
-		
+		client := c.createHTTPClient(task.Proxy, correlationID, jar, task.Config.FastMode)
+
 		// Build request
 		req, err = c.buildRequest(task.Combo, task.Config)
 		if err != nil {
 			// If we can't build the request, don't retry
-			c.logger.Error(fmt.Sprintf("Failed to build request for task %s", taskID), err, nil)
+			L.Error(taskCtx, "Failed to build request", err)
 			break
 		}
-		
+
+		if jar != nil && !seededJar {
+			seedComboCookieJar(jar, req.URL, task.Config)
+			seededJar = true
+		}
+
 		// Set request context
 		req = req.WithContext(ctx)
-		
-		// Log detailed request information
-		c.logDetailedRequest(req, retryCount+1, correlationID, task.Proxy)
-		
+
+		// Log detailed request information, if someone's watching for it
+		if logger.ShouldDebug(facilityHTTPTrace) {
+			c.logDetailedRequest(req, retryCount+1, correlationID, task.Proxy)
+		}
+
+		// Track this attempt's in-flight count and latency against whichever
+		// proxy it's using, feeding WeightedRoundRobin/LeastConn's signal
+		attemptStart := time.Now()
+		var markAttemptDone func(success bool, latency time.Duration)
+		if task.Proxy != nil {
+			markAttemptDone = c.proxyStats.BeginRequest(proxypolicy.ProxyKey(*task.Proxy))
+		}
+
 		// Execute request
 		resp, err = client.Do(req)
-		
+
+		if markAttemptDone != nil {
+			markAttemptDone(err == nil, time.Since(attemptStart))
+		}
+		if task.Proxy != nil && c.proxyHealthChecker != nil {
+			c.proxyHealthChecker.ReportResult(*task.Proxy, time.Since(attemptStart), err)
+		}
+
 		if err == nil {
-			// Log detailed response information
-			c.logDetailedResponse(resp, retryCount+1, correlationID, time.Since(start))
+			// Log detailed response information, if someone's watching for it
+			if logger.ShouldDebug(facilityHTTPTrace) {
+				c.logDetailedResponse(resp, retryCount+1, correlationID, time.Since(start))
+			}
 			break // Exit retry loop if request is successful
 		}
-		
+
 		// Log failed request
-		c.logger.LogNetworkRequest(req.Method, req.URL.String(), 0, time.Since(start), task.Proxy, correlationID, err)
+		L.Warn(taskCtx, "Network request failed", map[string]interface{}{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"error":  err.Error(),
+		})
 		retryCount++
-		
+
 		// Only retry if we have more attempts left
 		if retryCount < retryLimit {
-			c.logger.Warn(fmt.Sprintf("Retrying combo check for task %s (retry %d/%d) - %s", taskID, retryCount, retryLimit, err.Error()), nil)
-			
+			L.Warn(taskCtx, fmt.Sprintf("Retrying combo check (retry %d/%d)", retryCount, retryLimit), map[string]interface{}{
+				"error": err.Error(),
+			})
+
 			// For proxy-required configs, try to get a different proxy
 			if task.Config.RequiresProxy {
-				newProxy := c.getNextHealthyProxy()
+				newProxy := c.getNextHealthyProxy(task.Combo, task.Config)
 				if newProxy != nil {
 					task.Proxy = newProxy
+					taskCtx = logger.WithFields(taskCtx, map[string]interface{}{"proxy_host": newProxy.Host})
+					L = logger.FromContext(taskCtx)
 				} else {
-					c.logger.Warn(fmt.Sprintf("No healthy proxy available for retry %d", retryCount), nil)
+					L.Warn(taskCtx, fmt.Sprintf("No healthy proxy available for retry %d", retryCount))
 					// Continue with current proxy as last resort
 				}
 			} else if task.Config.UseProxy {
 				// Optional proxy usage - try another proxy or go without
-				task.Proxy = c.getNextProxy()
+				task.Proxy = c.getNextProxy(task.Combo, task.Config)
 			}
-			
+
 			// Add a small delay between retries to avoid overwhelming the server
 			time.Sleep(time.Duration(500*retryCount) * time.Millisecond)
 		}
 	}
-	
+
 	if err != nil {
-		c.logger.LogTaskComplete(taskID, "combo_check", correlationID, time.Since(start), false, err)
+		L.Error(taskCtx, "Task failed: combo_check", err, map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()})
 		return types.WorkerResult{
+			Ctx: taskCtx,
 			Result: types.CheckResult{
 				Combo:     task.Combo,
 				Config:    task.Config.Name,
@@ -545,15 +1172,21 @@ retryCount := 0
 		}
 	}
 	defer resp.Body.Close()
-	
+
 	// Log successful request
-	c.logger.LogNetworkRequest(req.Method, req.URL.String(), resp.StatusCode, time.Since(start), task.Proxy, correlationID, nil)
+	L.Info(taskCtx, "Network request succeeded", map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.String(),
+		"status_code": resp.StatusCode,
+		"latency_ms":  time.Since(start).Milliseconds(),
+	})
 
 	// Read response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		c.logger.LogTaskComplete(taskID, "combo_check", correlationID, time.Since(start), false, err)
+		L.Error(taskCtx, "Task failed: combo_check", err, map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()})
 		return types.WorkerResult{
+			Ctx: taskCtx,
 			Result: types.CheckResult{
 				Combo:     task.Combo,
 				Config:    task.Config.Name,
@@ -567,13 +1200,17 @@ retryCount := 0
 	}
 
 	// Analyze response
-	status := c.analyzeResponse(string(body), resp.StatusCode, task.Config)
+	status := c.analyzeResponse(taskCtx, string(body), resp.StatusCode, resp.Cookies(), task.Config)
 	duration := time.Since(start)
-	
+
 	// Log task completion
-	c.logger.LogTaskComplete(taskID, "combo_check", correlationID, duration, status == types.BotStatusSuccess, nil)
-	
+	L.Info(taskCtx, "Task completed: combo_check", map[string]interface{}{
+		"latency_ms": duration.Milliseconds(),
+		"success":    status == types.BotStatusSuccess,
+	})
+
 	return types.WorkerResult{
+		Ctx: taskCtx,
 		Result: types.CheckResult{
 			Combo:     task.Combo,
 			Config:    task.Config.Name,
@@ -591,28 +1228,113 @@ retryCount := 0
 // HTTP CLIENT MANAGEMENT
 // ============================================================================
 
-// createHTTPClient creates an azuretls HTTP client with optional proxy and timeout
-func (c *Checker) createHTTPClient(proxy *types.Proxy) httpclient.HTTPClientInterface {
+// newComboCookieJar creates a fresh, empty http.CookieJar scoped to a single
+// checkCombo call, backed by the public suffix list so cookies scope
+// correctly across subdomains (a.example.com can't read
+// b.example.com's session cookie) instead of the naive whole-domain
+// matching a hand-rolled jar would need to reimplement.
+func newComboCookieJar() (http.CookieJar, error) {
+	return cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+}
+
+// seedComboCookieJar pre-seeds jar with config.PreSeedCookies, letting
+// configs supply cookies (a consent banner, a locale, an A/B bucket) a flow
+// expects to already be set before its first request goes out.
+func seedComboCookieJar(jar http.CookieJar, target *url.URL, config types.Config) {
+	if jar == nil || target == nil || len(config.PreSeedCookies) == 0 {
+		return
+	}
+
+	cookies := make([]*http.Cookie, 0, len(config.PreSeedCookies))
+	for name, value := range config.PreSeedCookies {
+		cookies = append(cookies, &http.Cookie{Name: name, Value: value})
+	}
+	jar.SetCookies(target, cookies)
+}
+
+// attachCookieJar wires jar into an AzureTLSClient via its PreHook/CallBack
+// pipeline (see pkg/httpclient's interception pipeline) rather than the
+// client itself, since azuretls-client manages its own session and doesn't
+// accept a stdlib http.CookieJar directly.
+func attachCookieJar(client *httpclient.AzureTLSClient, jar http.CookieJar) {
+	client.PreHooks = append(client.PreHooks, func(req *http.Request) error {
+		for _, cookie := range jar.Cookies(req.URL) {
+			req.AddCookie(cookie)
+		}
+		return nil
+	})
+	client.CallBacks = append(client.CallBacks, func(req *http.Request, resp *http.Response) error {
+		if cookies := resp.Cookies(); len(cookies) > 0 {
+			jar.SetCookies(req.URL, cookies)
+		}
+		return nil
+	})
+}
+
+// createHTTPClient creates an azuretls HTTP client with optional proxy and
+// timeout, wrapping it in a ChaosTransport when chaos mode is enabled so
+// checkCombo's retry loop becomes something Config authors can actually
+// stress-test their success/failure string logic against. jar, if non-nil,
+// is attached so cookies persist across every request checkCombo makes for
+// one combo.
+//
+// If fastMode is set, this takes the fasthttp-backed fast path instead:
+// no cookie jar, no chaos wrapping, no azuretls fingerprinting - just the
+// lowest-allocation request/response round trip available, for rule files
+// that don't need any of that stdlib semantics.
+func (c *Checker) createHTTPClient(proxy *types.Proxy, correlationID string, jar http.CookieJar, fastMode bool) httpclient.HTTPClientInterface {
 	// Enforce maximum 30s timeout
 	timeout := time.Duration(c.Config.RequestTimeout) * time.Millisecond
 	if timeout > 30*time.Second {
 		timeout = 30 * time.Second
 	}
 
+	if fastMode {
+		fast, err := httpclient.NewFastHTTPClient(proxy, timeout)
+		if err == nil {
+			return fast
+		}
+		c.logger.Warn(c.ctx, "Failed to create fasthttp client, falling back to azuretls client", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
 	client, err := httpclient.NewAzureTLSClient(proxy, timeout)
+	var base httpclient.HTTPClientInterface
 	if err != nil {
 		// Fallback to standard HTTP client if azuretls fails
-		c.logger.Warn("Failed to create azuretls client, falling back to standard HTTP client", map[string]interface{}{
+		c.logger.Warn(c.ctx, "Failed to create azuretls client, falling back to standard HTTP client", map[string]interface{}{
 			"error": err.Error(),
 		})
-		return c.createFallbackHTTPClient(proxy, timeout)
+		base = c.createFallbackHTTPClient(proxy, timeout, jar)
+	} else {
+		if jar != nil {
+			attachCookieJar(client, jar)
+		}
+		base = client
+	}
+
+	if c.chaosEngine == nil {
+		return base
 	}
 
-	return client
+	chaos := httpclient.NewChaosTransport(base, c.chaosEngine, func(action httpclient.ChaosAction) (httpclient.HTTPClientInterface, error) {
+		return httpclient.NewAzureTLSClient(action.BadProxy, timeout)
+	})
+	chaos.OnInject = func(event httpclient.ChaosEvent) {
+		c.logger.Warn(c.ctx, fmt.Sprintf("Chaos injected: %s", event.Detail), map[string]interface{}{
+			"correlation_id": correlationID,
+			"chaos_host":     event.Host,
+			"chaos_action":   string(event.Action.Type),
+		})
+	}
+	return chaos
 }
 
-// createFallbackHTTPClient creates a standard HTTP client as fallback
-func (c *Checker) createFallbackHTTPClient(proxy *types.Proxy, timeout time.Duration) *http.Client {
+// createFallbackHTTPClient creates a standard HTTP client as fallback. jar,
+// if non-nil, is attached directly since *http.Client natively supports
+// http.CookieJar.
+func (c *Checker) createFallbackHTTPClient(proxy *types.Proxy, timeout time.Duration, jar http.CookieJar) *http.Client {
 	transport := &http.Transport{
 		TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
 		ResponseHeaderTimeout: 30 * time.Second,
@@ -631,6 +1353,7 @@ func (c *Checker) createFallbackHTTPClient(proxy *types.Proxy, timeout time.Dura
 
 	return &http.Client{
 		Transport: transport,
+		Jar:       jar,
 		Timeout:   timeout,
 	}
 }
@@ -701,16 +1424,37 @@ func (c *Checker) replaceVariables(text string, combo types.Combo) string {
 }
 
 // analyzeResponse analyzes the response to determine success/failure
-func (c *Checker) analyzeResponse(body string, statusCode int, config types.Config) types.BotStatus {
+func (c *Checker) analyzeResponse(ctx context.Context, body string, statusCode int, cookies []*http.Cookie, config types.Config) types.BotStatus {
+	L := logger.FromContext(ctx)
+
 	// Check status codes first
 	for _, successCode := range config.SuccessStatus {
 		if statusCode == successCode {
+			L.Debug(ctx, "Classified by status code", map[string]interface{}{"status_code": statusCode, "result": "success"})
 			return types.BotStatusSuccess
 		}
 	}
-	
+
 	for _, failureCode := range config.FailureStatus {
 		if statusCode == failureCode {
+			L.Debug(ctx, "Classified by status code", map[string]interface{}{"status_code": statusCode, "result": "fail"})
+			return types.BotStatusFail
+		}
+	}
+
+	// Check cookie assertions - some flows only ever signal success/failure
+	// through a Set-Cookie (a "session_verified" cookie, a fraud-score cookie)
+	// rather than the status code or body.
+	for _, cond := range config.SuccessCookies {
+		if cookieAssertionMatches(cookies, cond) {
+			L.Debug(ctx, "Classified by cookie assertion", map[string]interface{}{"cookie": cond.Name, "result": "success"})
+			return types.BotStatusSuccess
+		}
+	}
+
+	for _, cond := range config.FailureCookies {
+		if cookieAssertionMatches(cookies, cond) {
+			L.Debug(ctx, "Classified by cookie assertion", map[string]interface{}{"cookie": cond.Name, "result": "fail"})
 			return types.BotStatusFail
 		}
 	}
@@ -718,6 +1462,7 @@ func (c *Checker) analyzeResponse(body string, statusCode int, config types.Conf
 	// Check success strings
 	for _, successStr := range config.SuccessStrings {
 		if strings.Contains(body, successStr) {
+			L.Debug(ctx, "Classified by success string", map[string]interface{}{"match": successStr})
 			return types.BotStatusSuccess
 		}
 	}
@@ -725,38 +1470,67 @@ func (c *Checker) analyzeResponse(body string, statusCode int, config types.Conf
 	// Check failure strings
 	for _, failureStr := range config.FailureStrings {
 		if strings.Contains(body, failureStr) {
+			L.Debug(ctx, "Classified by failure string", map[string]interface{}{"match": failureStr})
 			return types.BotStatusFail
 		}
 	}
 
 	// Default to invalid if no specific conditions match
+	L.Debug(ctx, "No success/failure condition matched, defaulting to fail")
 	return types.BotStatusFail
 }
 
+// cookieAssertionMatches reports whether cookies satisfies cond: cond.Name
+// must be present, and if cond.Contains is set its value must be a substring
+// of the cookie's value, and if cond.RequireNonEmpty is set the cookie's
+// value must be non-empty.
+func cookieAssertionMatches(cookies []*http.Cookie, cond types.CookieAssertion) bool {
+	for _, cookie := range cookies {
+		if cookie.Name != cond.Name {
+			continue
+		}
+		if cond.RequireNonEmpty && cookie.Value == "" {
+			return false
+		}
+		if cond.Contains != "" && !strings.Contains(cookie.Value, cond.Contains) {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
 // ============================================================================
 // RESULT PROCESSING AND STATISTICS
 // ============================================================================
 
 // handleResult processes a single worker result with logging and persistence
 func (c *Checker) handleResult(result types.WorkerResult) {
+	ctx := result.Ctx
+	if ctx == nil {
+		ctx = c.ctx
+	}
+	L := logger.FromContext(ctx)
+
 	c.updateStats(result.Result)
-	
+
+	if c.streamServer != nil {
+		c.streamServer.PublishResult(result.Result)
+	}
+
 	// Log successful results
 	if result.Result.Status == types.BotStatusSuccess {
 		c.logger.LogCheckerEvent("valid_combo_found", result.Result, nil)
 	}
-	
+
 	// Log errors
 	if result.Error != nil {
-		c.logger.Error("Worker error", result.Error, map[string]interface{}{
-			"combo": result.Result.Combo.Username,
-			"config": result.Result.Config,
-		})
+		L.Error(ctx, "Worker error", result.Error)
 	}
-	
+
 	// Save result if needed
 	if !c.Config.SaveValidOnly || result.Result.Status == types.BotStatusSuccess {
-		c.saveResult(result.Result)
+		c.saveResult(ctx, result.Result)
 	}
 }
 
@@ -771,8 +1545,7 @@ func (c *Checker) processResults() {
 // THREAD-SAFETY: Acquires statsMutex (write lock) to ensure atomic stat updates
 // Called concurrently by handleResult() from result processor goroutine
 func (c *Checker) updateStats(result types.CheckResult) {
-	c.statsMutex.Lock()   // Exclusive write access to Stats
-	defer c.statsMutex.Unlock()
+	c.statsMutex.Lock() // Exclusive write access to Stats
 
 	switch result.Status {
 	case types.BotStatusSuccess:
@@ -789,12 +1562,19 @@ func (c *Checker) updateStats(result types.CheckResult) {
 		totalChecks := c.Stats.ValidCombos + c.Stats.InvalidCombos + c.Stats.ErrorCombos
 		c.Stats.CurrentCPM = float64(totalChecks) / elapsed
 	}
+
+	c.statsMutex.Unlock()
+
+	// A combo just finished processing - a meaningful change for any
+	// StatsStream subscriber. GetStats takes its own RLock, so this must
+	// run after statsMutex is released above.
+	c.publishStatsChange(c.GetStats())
 }
 
 // saveResult saves a result to file
-func (c *Checker) saveResult(result types.CheckResult) {
+func (c *Checker) saveResult(ctx context.Context, result types.CheckResult) {
 	if err := c.exporter.ExportResult(result); err != nil {
-	log.Printf("[ERROR] Failed to export result: %v", err)
+		logger.FromContext(ctx).Error(ctx, "Failed to export result", err)
 	}
 }
 
@@ -802,46 +1582,115 @@ func (c *Checker) saveResult(result types.CheckResult) {
 // PROXY MANAGEMENT
 // ============================================================================
 
-// getNextProxy returns the next proxy using the advanced proxy manager
-// THREAD-SAFETY: Uses proxyMutex for fallback rotation to ensure atomic index increment
+// getNextProxy returns the next proxy using the advanced proxy manager,
+// falling back to combo/config's configured ProxySelectionPolicy (see
+// pkg/proxypolicy) over a snapshot of c.Proxies when the manager has no
+// healthy candidate yet.
+// THREAD-SAFETY: snapshots c.Proxies under proxyMutex; the resolved policy
+// guards its own state.
 // Called concurrently by createTask() during task generation
-func (c *Checker) getNextProxy() *types.Proxy {
+func (c *Checker) getNextProxy(combo types.Combo, config types.Config) *types.Proxy {
 	// Use the advanced proxy manager to get the best proxy
 	proxy, err := c.proxyManager.GetBestProxy()
+	if err == nil {
+		return proxy
+	}
+
+	// Fallback: snapshot c.Proxies and hand off to the config's selection
+	// policy instead of the old hard-coded rotation/random branch
+	proxies := c.snapshotProxies()
+	if len(proxies) == 0 {
+		return nil
+	}
+
+	// Restrict the candidate set to proxies the health checker hasn't
+	// ejected, falling back to the full set if every proxy is currently
+	// ejected rather than failing the task outright.
+	if healthy := c.filterHealthyProxies(proxies); len(healthy) > 0 {
+		proxies = healthy
+	}
+
+	policy, err := c.resolveProxyPolicy(config)
 	if err != nil {
-		// Fallback to simple rotation if advanced manager fails
-		c.proxyMutex.Lock()   // Exclusive access to proxyIndex
-		defer c.proxyMutex.Unlock()
-		
-		if len(c.Proxies) == 0 {
-			return nil
+		c.logger.Warn(c.ctx, fmt.Sprintf("Unknown proxy policy %q, falling back to round robin", config.ProxyPolicy), nil)
+		policy, _ = c.proxyPolicies.Resolve("round_robin")
+	}
+
+	selected, err := policy.Select(proxies, c.proxySelectionContext(combo, config))
+	if err != nil {
+		return nil
+	}
+	return selected
+}
+
+// snapshotProxies returns a copy of c.Proxies safe to range over or hand to
+// a selection policy without holding proxyMutex.
+func (c *Checker) snapshotProxies() []types.Proxy {
+	c.proxyMutex.Lock()
+	defer c.proxyMutex.Unlock()
+	return append([]types.Proxy(nil), c.Proxies...)
+}
+
+// isProxyHealthy reports whether proxy is currently eligible for use per the
+// active/passive health checker, defaulting to true when health-checking
+// isn't configured.
+func (c *Checker) isProxyHealthy(proxy types.Proxy) bool {
+	if c.proxyHealthChecker == nil {
+		return true
+	}
+	return c.proxyHealthChecker.IsHealthy(proxy)
+}
+
+// filterHealthyProxies returns the subset of proxies the health checker
+// hasn't ejected.
+func (c *Checker) filterHealthyProxies(proxies []types.Proxy) []types.Proxy {
+	healthy := make([]types.Proxy, 0, len(proxies))
+	for _, proxy := range proxies {
+		if c.isProxyHealthy(proxy) {
+			healthy = append(healthy, proxy)
 		}
-		
+	}
+	return healthy
+}
+
+// resolveProxyPolicy resolves config.ProxyPolicy to a cached policy
+// instance, preserving the old ProxyRotation-bool default (round robin vs.
+// random) for configs that don't set ProxyPolicy explicitly.
+func (c *Checker) resolveProxyPolicy(config types.Config) (proxypolicy.ProxySelectionPolicy, error) {
+	name := config.ProxyPolicy
+	if name == "" {
 		if c.Config.ProxyRotation {
-			proxy := &c.Proxies[c.proxyIndex]
-			c.proxyIndex = (c.proxyIndex + 1) % len(c.Proxies)
-			return proxy
+			name = "round_robin"
+		} else {
+			name = "random"
 		}
-		
-		// Random proxy selection
-		return &c.Proxies[rand.Intn(len(c.Proxies))]
 	}
-	
-	return proxy
+	return c.proxyPolicies.Resolve(name)
+}
+
+// proxySelectionContext builds the session-affinity signal IPHash/HeaderHash
+// hash against, resolving config.ProxyPolicyHeaderName to its static header
+// value if one is configured.
+func (c *Checker) proxySelectionContext(combo types.Combo, config types.Config) proxypolicy.SelectionContext {
+	selCtx := proxypolicy.SelectionContext{ComboUsername: combo.Username}
+	if config.ProxyPolicyHeaderName != "" {
+		selCtx.HeaderValue = config.Headers[config.ProxyPolicyHeaderName]
+	}
+	return selCtx
 }
 
 // getNextHealthyProxy returns the next healthy proxy with fallback logic
-func (c *Checker) getNextHealthyProxy() *types.Proxy {
+func (c *Checker) getNextHealthyProxy(combo types.Combo, config types.Config) *types.Proxy {
 	// Try to get a healthy proxy multiple times
 	for attempts := 0; attempts < 5; attempts++ {
-		proxy := c.getNextProxy()
-		if proxy != nil && proxy.Working {
+		proxy := c.getNextProxy(combo, config)
+		if proxy != nil && proxy.Working && c.isProxyHealthy(*proxy) {
 			return proxy
 		}
 	}
-	
+
 	// If no healthy proxy found, return any proxy (might be marked as unhealthy but could still work)
-	return c.getNextProxy()
+	return c.getNextProxy(combo, config)
 }
 
 // shouldSkipTaskDueToProxy determines if a task should be skipped due to proxy requirements
@@ -850,14 +1699,14 @@ func (c *Checker) shouldSkipTaskDueToProxy(config types.Config) bool {
 		// Config absolutely requires a proxy
 		if len(c.Proxies) == 0 {
 			// No proxies available at all
-			c.logger.Warn(fmt.Sprintf("Skipping config %s - requires proxy but none available", config.Name), nil)
+			c.logger.Warn(c.ctx, fmt.Sprintf("Skipping config %s - requires proxy but none available", config.Name), nil)
 			return true
 		}
 		
 		// Check if we have any working proxies
 		workingProxies := c.getWorkingProxies()
 		if len(workingProxies) == 0 {
-			c.logger.Warn(fmt.Sprintf("Skipping config %s - requires proxy but all proxies are dead", config.Name), nil)
+			c.logger.Warn(c.ctx, fmt.Sprintf("Skipping config %s - requires proxy but all proxies are dead", config.Name), nil)
 			return true
 		}
 	}
@@ -887,40 +1736,183 @@ func (c *Checker) parseCombo(line string) *types.Combo {
 	return combo
 }
 
-// parseProxy parses a proxy line into a Proxy struct
-func (c *Checker) parseProxy(line string) *types.Proxy {
+// ProxyParseError describes why a single proxy line was rejected, letting
+// LoadProxies/parseProxyList report which line failed instead of the old
+// behavior of silently dropping it.
+type ProxyParseError struct {
+	Line int
+	Raw  string
+	Err  error
+}
+
+func (e *ProxyParseError) Error() string {
+	return fmt.Sprintf("proxy line %d (%q): %v", e.Line, e.Raw, e.Err)
+}
+
+func (e *ProxyParseError) Unwrap() error { return e.Err }
+
+// parseProxyType normalizes a scheme/type token into a types.ProxyType,
+// including socks5h - the SOCKS5 variant that resolves DNS through the
+// proxy rather than locally, which scrapes of SOCKS5-over-proxy lists
+// frequently advertise.
+func parseProxyType(scheme string) (types.ProxyType, error) {
+	switch strings.ToLower(scheme) {
+	case "", "http":
+		return types.ProxyTypeHTTP, nil
+	case "https":
+		return types.ProxyTypeHTTPS, nil
+	case "socks4":
+		return types.ProxyTypeSOCKS4, nil
+	case "socks5":
+		return types.ProxyTypeSOCKS5, nil
+	case "socks5h":
+		return types.ProxyTypeSOCKS5H, nil
+	default:
+		return "", fmt.Errorf("unrecognized proxy scheme %q", scheme)
+	}
+}
+
+// parseProxy parses a single proxy line into a Proxy struct, recognizing
+// five shapes:
+//
+//   - host:port
+//   - host:port:type
+//   - host:port:user:pass
+//   - user:pass@host:port
+//   - scheme://[user:pass@]host:port
+//
+// It returns a structured error instead of nil so callers can report which
+// line failed and why, rather than silently skipping it.
+func (c *Checker) parseProxy(line string) (*types.Proxy, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, fmt.Errorf("empty proxy line")
+	}
+
+	if strings.Contains(line, "://") {
+		return parseProxyURL(line)
+	}
+
+	if idx := strings.LastIndex(line, "@"); idx != -1 {
+		return parseProxyUserinfo(line[:idx], line[idx+1:])
+	}
+
+	return parseProxyPlain(line)
+}
+
+// parseProxyURL parses the scheme://[user:pass@]host:port shape via
+// net/url, the same primitive http.ProxyURL consumes downstream.
+func parseProxyURL(line string) (*types.Proxy, error) {
+	u, err := url.Parse(line)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	proxyType, err := parseProxyType(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	host, portStr, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy host:port %q: %w", u.Host, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy port %q: %w", portStr, err)
+	}
+
+	proxy := &types.Proxy{Host: host, Port: port, Type: proxyType}
+	if u.User != nil {
+		proxy.Username = u.User.Username()
+		proxy.Password, _ = u.User.Password()
+	}
+	return proxy, nil
+}
+
+// parseProxyUserinfo parses the user:pass@host:port shape, where cred is
+// "user:pass" and hostport is "host:port".
+func parseProxyUserinfo(cred, hostport string) (*types.Proxy, error) {
+	user, pass, ok := strings.Cut(cred, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid proxy credentials %q, expected user:pass", cred)
+	}
+
+	proxy, err := parseProxyPlain(hostport)
+	if err != nil {
+		return nil, err
+	}
+	proxy.Username = user
+	proxy.Password = pass
+	return proxy, nil
+}
+
+// parseProxyPlain parses the colon-delimited shapes that carry no "://" or
+// "@": host:port, host:port:type, and host:port:user:pass.
+func parseProxyPlain(line string) (*types.Proxy, error) {
 	parts := strings.Split(line, ":")
 	if len(parts) < 2 {
-		return nil
+		return nil, fmt.Errorf("expected host:port, got %q", line)
 	}
 
-	proxy := &types.Proxy{
-		Host: parts[0],
-		Port: c.parseInt(parts[1]),
-		Type: types.ProxyTypeHTTP, // Default to HTTP
+	port, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy port %q: %w", parts[1], err)
 	}
 
-	// Try to detect proxy type from line
-	if len(parts) > 2 {
-		switch strings.ToLower(parts[2]) {
-		case "socks4":
-			proxy.Type = types.ProxyTypeSOCKS4
-		case "socks5":
-			proxy.Type = types.ProxyTypeSOCKS5
-		case "https":
-			proxy.Type = types.ProxyTypeHTTPS
+	proxy := &types.Proxy{Host: parts[0], Port: port, Type: types.ProxyTypeHTTP}
+
+	switch len(parts) {
+	case 2:
+		// host:port
+	case 3:
+		proxyType, err := parseProxyType(parts[2])
+		if err != nil {
+			return nil, err
 		}
+		proxy.Type = proxyType
+	case 4:
+		proxy.Username = parts[2]
+		proxy.Password = parts[3]
+	default:
+		return nil, fmt.Errorf("unrecognized proxy line format %q", line)
 	}
 
-	return proxy
+	return proxy, nil
 }
 
-// parseInt parses a string to integer
-func (c *Checker) parseInt(s string) int {
-	if i, err := strconv.Atoi(s); err == nil {
-		return i
+// parseProxyList streams r line by line, parsing each with parseProxy and
+// deduplicating by host:port, instead of LoadProxies' old approach of
+// reading the whole file into memory before parsing. Blank lines and lines
+// starting with "#" are skipped; any other line that fails to parse aborts
+// the whole load with a ProxyParseError naming the offending line.
+func (c *Checker) parseProxyList(r io.Reader) ([]types.Proxy, error) {
+	seen := make(map[string]struct{})
+	var proxies []types.Proxy
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		proxy, err := c.parseProxy(line)
+		if err != nil {
+			return nil, &ProxyParseError{Line: lineNum, Raw: line, Err: err}
+		}
+
+		key := fmt.Sprintf("%s:%d", proxy.Host, proxy.Port)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		proxies = append(proxies, *proxy)
 	}
-	return 0
+
+	return proxies, scanner.Err()
 }
 
 // GetStats returns current statistics with concurrent read lock
@@ -932,23 +1924,74 @@ func (c *Checker) GetStats() types.CheckerStats {
 	
 	stats := *c.Stats
 	stats.ElapsedTime = int(time.Since(c.Stats.StartTime).Seconds())
-	stats.ActiveWorkers = c.Config.MaxWorkers
+	stats.ActiveWorkers = c.comboPool.Size()
 	stats.WorkingProxies = len(c.getWorkingProxies())
-	
+	stats.ProxyHealth = c.ProxyHealth()
+	stats.PoolStats = []workers.Metrics{c.comboPool.Metrics(), c.resultPool.Metrics()}
+
 	return stats
 }
 
-// getWorkingProxies returns only working proxies
+// StatsStream returns a channel that receives a Stats snapshot on every
+// meaningful state change (a combo processed, the worker pool resized, ...)
+// instead of requiring the caller to poll GetStats on its own ticker. The
+// channel is single-slot and coalescing: a snapshot published while one is
+// already pending replaces it rather than queuing, so a slow or absent
+// consumer never backs up the callers that publish to it (handleResult,
+// Resize, ScaleWorkers). Stop does not close it - read ctx.Done or the
+// consumer's own lifecycle to know when to stop reading.
+func (c *Checker) StatsStream() <-chan types.CheckerStats {
+	return c.statsStream
+}
+
+// publishStatsChange offers stats to statsStream without blocking, and
+// forwards the same snapshot to the /ws/stats live-streaming subscribers
+// when a stream server is configured, so both surfaces stay in lockstep off
+// one call site instead of a second polling loop. If statsStream's single
+// slot is full, it drops whatever's buffered in favor of this newer
+// snapshot - callers on the hot result-processing path never wait on a
+// StatsStream subscriber that isn't keeping up.
+func (c *Checker) publishStatsChange(stats types.CheckerStats) {
+	select {
+	case c.statsStream <- stats:
+	default:
+		select {
+		case <-c.statsStream:
+		default:
+		}
+		select {
+		case c.statsStream <- stats:
+		default:
+		}
+	}
+
+	if c.streamServer != nil {
+		c.streamServer.PublishStats(stats)
+	}
+}
+
+// getWorkingProxies returns only working proxies that the health checker
+// hasn't ejected
 func (c *Checker) getWorkingProxies() []types.Proxy {
 	var working []types.Proxy
 	for _, proxy := range c.Proxies {
-		if proxy.Working {
+		if proxy.Working && c.isProxyHealthy(proxy) {
 			working = append(working, proxy)
 		}
 	}
 	return working
 }
 
+// ProxyHealth returns the active/passive health table tracked by the proxy
+// health checker, keyed by proxypolicy.ProxyKey, or nil if health-checking
+// isn't configured. It satisfies adminapi.CheckerAPI's JSON health endpoint.
+func (c *Checker) ProxyHealth() map[string]proxypolicy.ProxyHealth {
+	if c.proxyHealthChecker == nil {
+		return nil
+	}
+	return c.proxyHealthChecker.Table()
+}
+
 // ============================================================================
 // LOGGING METHODS
 // ============================================================================
@@ -973,7 +2016,7 @@ func (c *Checker) logDetailedRequest(req *http.Request, reqNumber int, correlati
 	}
 	
 	// Log detailed request
-	c.logger.Info("=== DETAILED REQUEST ===", map[string]interface{}{
+	c.logger.Info(c.ctx, "=== DETAILED REQUEST ===", map[string]interface{}{
 		"correlation_id": correlationID,
 		"request_number": reqNumber,
 		"proxy": proxyInfo,
@@ -1001,7 +2044,7 @@ func (c *Checker) logDetailedResponse(resp *http.Response, reqNumber int, correl
 	}
 	
 	// Log detailed response
-	c.logger.Info("=== DETAILED RESPONSE ===", map[string]interface{}{
+	c.logger.Info(c.ctx, "=== DETAILED RESPONSE ===", map[string]interface{}{
 		"correlation_id": correlationID,
 		"request_number": reqNumber,
 		"duration_ms": duration.Milliseconds(),
@@ -1073,11 +2116,11 @@ func (c *Checker) ShouldSkipTaskDueToProxy(config types.Config) bool {
 }
 
 // GetNextProxy exposes the private method for testing
-func (c *Checker) GetNextProxy() *types.Proxy {
-	return c.getNextProxy()
+func (c *Checker) GetNextProxy(combo types.Combo, config types.Config) *types.Proxy {
+	return c.getNextProxy(combo, config)
 }
 
 // GetNextHealthyProxy exposes the private method for testing
-func (c *Checker) GetNextHealthyProxy() *types.Proxy {
-	return c.getNextHealthyProxy()
+func (c *Checker) GetNextHealthyProxy(combo types.Combo, config types.Config) *types.Proxy {
+	return c.getNextHealthyProxy(combo, config)
 }
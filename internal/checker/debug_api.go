@@ -0,0 +1,126 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"universal-checker/internal/logger"
+)
+
+// debugLogHistoryLimit bounds how many buffered entries GET /log returns.
+const debugLogHistoryLimit = 250
+
+// debugAPIServer hosts the small, opt-in REST surface for runtime debug
+// facility toggling and log tailing. It's intentionally separate from
+// adminapi.Server: that one mutates configs/proxies/workers behind a shared
+// secret, this one only ever reads logging state or flips a debug facility,
+// so it's meant to be safe enough to leave on for the life of a run and
+// curl whenever a paste-ready trace is needed.
+type debugAPIServer struct {
+	checker    *Checker
+	httpServer *http.Server
+}
+
+// newDebugAPIServer builds (without starting) a debugAPIServer listening on
+// addr.
+func newDebugAPIServer(addr string, checker *Checker) *debugAPIServer {
+	d := &debugAPIServer{checker: checker}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/facilities", d.handleFacilities)
+	mux.HandleFunc("/log", d.handleLog)
+
+	d.httpServer = &http.Server{Addr: addr, Handler: mux}
+	return d
+}
+
+// Start begins listening in a background goroutine.
+func (d *debugAPIServer) Start() error {
+	ln, err := net.Listen("tcp", d.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	go d.httpServer.Serve(ln)
+	return nil
+}
+
+// Stop gracefully shuts the listener down.
+func (d *debugAPIServer) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	d.httpServer.Shutdown(ctx)
+}
+
+// handleFacilities handles GET (list every registered facility + status) and
+// POST (enable/disable a set by name) requests against /debug/facilities.
+func (d *debugAPIServer) handleFacilities(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeDebugJSON(w, http.StatusOK, logger.Facilities())
+
+	case http.MethodPost:
+		var body struct {
+			Enable  []string `json:"enable"`
+			Disable []string `json:"disable"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeDebugError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		for _, name := range body.Enable {
+			logger.SetFacility(name, true)
+		}
+		for _, name := range body.Disable {
+			logger.SetFacility(name, false)
+		}
+		writeDebugJSON(w, http.StatusOK, logger.Facilities())
+
+	default:
+		writeDebugError(w, http.StatusMethodNotAllowed, "expected GET or POST")
+	}
+}
+
+// handleLog handles GET /log?since=<unix-seconds>, returning up to
+// debugLogHistoryLimit buffered entries, optionally filtered to those after
+// since.
+func (d *debugAPIServer) handleLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeDebugError(w, http.StatusMethodNotAllowed, "expected GET")
+		return
+	}
+
+	entries := d.checker.logger.GetRecentLogs(debugLogHistoryLimit)
+
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		sec, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			writeDebugError(w, http.StatusBadRequest, "invalid since: "+err.Error())
+			return
+		}
+
+		since := time.Unix(sec, 0)
+		filtered := entries[:0]
+		for _, entry := range entries {
+			if entry.Timestamp.After(since) {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	writeDebugJSON(w, http.StatusOK, entries)
+}
+
+func writeDebugJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeDebugError(w http.ResponseWriter, status int, message string) {
+	writeDebugJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,90 @@
+// Package inputs holds the run-validation rules shared by every front-end
+// (GUI, CLI, ...) that builds a types.CheckerConfig from user-supplied
+// paths and numeric settings. It exists so the bounds-checking logic isn't
+// copy-pasted per front-end and drifting: a combo file rejected by one
+// surface must be rejected by all of them.
+package inputs
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NumericBounds describes the default/min/max policy for a bounded integer
+// setting (workers, timeout, ...).
+type NumericBounds struct {
+	Default, Min, Max int
+}
+
+// WorkersBounds and TimeoutBounds are the bounds every front-end applies to
+// the worker-count and request-timeout(ms) settings, matching the original
+// GUI defaults.
+var (
+	WorkersBounds = NumericBounds{Default: 100, Min: 1, Max: 1000}
+	TimeoutBounds = NumericBounds{Default: 30000, Min: 1000, Max: 300000}
+)
+
+// ValidateNumeric parses input, falling back to b.Default when it's blank
+// or unparsable, then clamps the result to [b.Min, b.Max].
+func ValidateNumeric(input string, b NumericBounds) int {
+	if strings.TrimSpace(input) == "" {
+		return b.Default
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		return b.Default
+	}
+
+	if value < b.Min {
+		return b.Min
+	}
+	if value > b.Max {
+		return b.Max
+	}
+
+	return value
+}
+
+// RunInputs is the set of fields every front-end must validate identically
+// before a checker run can start.
+type RunInputs struct {
+	ComboPath      string
+	ProxyPath      string
+	SelectedConfig int // count of configs selected/passed for this run
+}
+
+// Validate applies the bounds checks a run must pass before startChecking
+// (or its CLI equivalent) may build a types.CheckerConfig: a combo file must
+// be selected and exist, an optional proxy file must exist if given, and at
+// least one config must be selected.
+func (r RunInputs) Validate() error {
+	if r.ComboPath == "" {
+		return fmt.Errorf("please select a combo file")
+	}
+
+	if !FileExists(r.ComboPath) {
+		return fmt.Errorf("combo file does not exist: %s", r.ComboPath)
+	}
+
+	if r.ProxyPath != "" && !FileExists(r.ProxyPath) {
+		return fmt.Errorf("proxy file does not exist: %s", r.ProxyPath)
+	}
+
+	if r.SelectedConfig == 0 {
+		return fmt.Errorf("please select at least one configuration")
+	}
+
+	return nil
+}
+
+// FileExists reports whether path names a file that can be stat'd.
+func FileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,26 @@
+package logger
+
+import "context"
+
+// NoopLogger discards every call. It exists so tests and benchmarks can
+// exercise checker/proxy code paths that require a Logger without the
+// overhead or output noise of a real backend.
+type NoopLogger struct{}
+
+// NewNoopLogger returns a Logger that discards everything it's given.
+func NewNoopLogger() *NoopLogger {
+	return &NoopLogger{}
+}
+
+func (NoopLogger) Debug(ctx context.Context, message string, fields ...map[string]interface{}) {}
+func (NoopLogger) Info(ctx context.Context, message string, fields ...map[string]interface{})  {}
+func (NoopLogger) Warn(ctx context.Context, message string, fields ...map[string]interface{})  {}
+func (NoopLogger) Error(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+}
+func (NoopLogger) Fatal(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+}
+func (NoopLogger) V(level LogLevel) bool                     { return false }
+func (n NoopLogger) With(base map[string]interface{}) Logger { return n }
+func (NoopLogger) Flush(ctx context.Context) error           { return nil }
+
+var _ Logger = NoopLogger{}
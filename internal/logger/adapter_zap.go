@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// zapLogger adapts a *zap.SugaredLogger to the Logger interface for
+// deployments that want zap's allocation-light encoders on the hot checker
+// path instead of the default slog-backed StructuredLogger.
+type zapLogger struct {
+	sugar *zap.SugaredLogger
+	level LogLevel
+	base  map[string]interface{}
+}
+
+func newZapLogger(config LoggerConfig) (Logger, error) {
+	zapConfig := zap.NewProductionConfig()
+	if config.JSONFormat {
+		zapConfig.Encoding = "json"
+	} else {
+		zapConfig.Encoding = "console"
+	}
+	if config.OutputFile != "" {
+		zapConfig.OutputPaths = append(zapConfig.OutputPaths, config.OutputFile)
+	}
+	zapConfig.Level = zap.NewAtomicLevelAt(toZapLevel(config.Level))
+
+	built, err := zapConfig.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	sugar := built.Sugar()
+	if config.Component != "" {
+		sugar = sugar.With("component", config.Component)
+	}
+
+	return &zapLogger{sugar: sugar, level: config.Level}, nil
+}
+
+func toZapLevel(level LogLevel) zapcore.Level {
+	switch level {
+	case DEBUG:
+		return zapcore.DebugLevel
+	case WARN:
+		return zapcore.WarnLevel
+	case ERROR, FATAL:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+func (z *zapLogger) args(fields []map[string]interface{}) []interface{} {
+	args := make([]interface{}, 0, (len(z.base)+len(fields)*2)*2)
+	for k, v := range z.base {
+		args = append(args, k, v)
+	}
+	for _, fieldMap := range fields {
+		for k, v := range fieldMap {
+			args = append(args, k, v)
+		}
+	}
+	return args
+}
+
+func (z *zapLogger) Debug(ctx context.Context, message string, fields ...map[string]interface{}) {
+	z.sugar.Debugw(message, z.args(fields)...)
+}
+
+func (z *zapLogger) Info(ctx context.Context, message string, fields ...map[string]interface{}) {
+	z.sugar.Infow(message, z.args(fields)...)
+}
+
+func (z *zapLogger) Warn(ctx context.Context, message string, fields ...map[string]interface{}) {
+	z.sugar.Warnw(message, z.args(fields)...)
+}
+
+func (z *zapLogger) Error(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	args := z.args(fields)
+	if err != nil {
+		args = append(args, "error", err.Error())
+	}
+	z.sugar.Errorw(message, args...)
+}
+
+func (z *zapLogger) Fatal(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	args := z.args(fields)
+	if err != nil {
+		args = append(args, "error", err.Error())
+	}
+	z.sugar.Fatalw(message, args...)
+}
+
+func (z *zapLogger) V(level LogLevel) bool {
+	return level >= z.level
+}
+
+func (z *zapLogger) With(base map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(z.base)+len(base))
+	for k, v := range z.base {
+		merged[k] = v
+	}
+	for k, v := range base {
+		merged[k] = v
+	}
+	return &zapLogger{sugar: z.sugar, level: z.level, base: merged}
+}
+
+func (z *zapLogger) Flush(ctx context.Context) error {
+	return z.sugar.Sync()
+}
+
+var _ Logger = (*zapLogger)(nil)
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// zerologLogger adapts a zerolog.Logger to the Logger interface for
+// deployments that want zerolog's zero-allocation JSON encoder.
+type zerologLogger struct {
+	log   zerolog.Logger
+	level LogLevel
+}
+
+func newZerologLogger(config LoggerConfig) (Logger, error) {
+	var output *os.File = os.Stdout
+	if config.OutputFile != "" {
+		file, err := os.OpenFile(config.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		output = file
+	}
+
+	zlog := zerolog.New(output).With().Timestamp().Logger().Level(toZerologLevel(config.Level))
+	if config.Component != "" {
+		zlog = zlog.With().Str("component", config.Component).Logger()
+	}
+
+	return &zerologLogger{log: zlog, level: config.Level}, nil
+}
+
+func toZerologLevel(level LogLevel) zerolog.Level {
+	switch level {
+	case DEBUG:
+		return zerolog.DebugLevel
+	case WARN:
+		return zerolog.WarnLevel
+	case ERROR:
+		return zerolog.ErrorLevel
+	case FATAL:
+		return zerolog.FatalLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
+
+func withFields(event *zerolog.Event, fields []map[string]interface{}) *zerolog.Event {
+	for _, fieldMap := range fields {
+		for k, v := range fieldMap {
+			event = event.Interface(k, v)
+		}
+	}
+	return event
+}
+
+func (z *zerologLogger) Debug(ctx context.Context, message string, fields ...map[string]interface{}) {
+	withFields(z.log.Debug(), fields).Msg(message)
+}
+
+func (z *zerologLogger) Info(ctx context.Context, message string, fields ...map[string]interface{}) {
+	withFields(z.log.Info(), fields).Msg(message)
+}
+
+func (z *zerologLogger) Warn(ctx context.Context, message string, fields ...map[string]interface{}) {
+	withFields(z.log.Warn(), fields).Msg(message)
+}
+
+func (z *zerologLogger) Error(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	event := z.log.Error()
+	if err != nil {
+		event = event.Err(err)
+	}
+	withFields(event, fields).Msg(message)
+}
+
+func (z *zerologLogger) Fatal(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	event := z.log.Fatal()
+	if err != nil {
+		event = event.Err(err)
+	}
+	withFields(event, fields).Msg(message)
+}
+
+func (z *zerologLogger) V(level LogLevel) bool {
+	return level >= z.level
+}
+
+func (z *zerologLogger) With(base map[string]interface{}) Logger {
+	ctx := z.log.With()
+	for k, v := range base {
+		ctx = ctx.Interface(k, v)
+	}
+	return &zerologLogger{log: ctx.Logger(), level: z.level}
+}
+
+func (z *zerologLogger) Flush(ctx context.Context) error {
+	return nil
+}
+
+var _ Logger = (*zerologLogger)(nil)
@@ -0,0 +1,303 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AsyncRotationConfig controls AsyncRotatingWriter's batching and rotation
+// policy. Unlike RotationConfig/RotatingWriter, which writes (and rotates)
+// synchronously on the calling goroutine, this buffers writes in memory and
+// lets a dedicated background goroutine own the file and *bufio.Writer, so a
+// hot logging path never blocks on disk I/O or rotation.
+type AsyncRotationConfig struct {
+	// FlushSize forces a flush of the pending buffer once it accumulates
+	// this many bytes, bounding memory use between periodic flushes. 0
+	// falls back to a 64 KiB default.
+	FlushSize int
+	// RotationInterval is both the periodic flush cadence (so a quiet
+	// logger still lands entries on disk promptly) and the forced
+	// rotation cadence, generalizing RotatingWriter's DailyRotation to an
+	// arbitrary period. 0 falls back to a 5 second default.
+	RotationInterval time.Duration
+	// MaxFileSize rotates the file after a flush leaves its on-disk size at
+	// or above this many bytes. 0 disables size-based rotation.
+	MaxFileSize int64
+	// MaxBackups caps how many rotated backups are kept; the oldest are
+	// pruned once the count is exceeded. 0 means unlimited.
+	MaxBackups int
+	// Compress gzips rotated backups asynchronously after rotation.
+	Compress bool
+}
+
+func (c AsyncRotationConfig) withDefaults() AsyncRotationConfig {
+	if c.FlushSize <= 0 {
+		c.FlushSize = 64 * 1024
+	}
+	if c.RotationInterval <= 0 {
+		c.RotationInterval = 5 * time.Second
+	}
+	return c
+}
+
+// AsyncRotatingWriter is an io.WriteCloser that batches writes through a
+// dedicated goroutine instead of touching the file on every Write call.
+// Producers append to a pending buffer under flushMu and signal flushCond;
+// the writer goroutine is the only one that ever touches the open file or
+// its *bufio.Writer, so Write itself stays a cheap append under a mutex.
+type AsyncRotatingWriter struct {
+	filename string
+	config   AsyncRotationConfig
+
+	flushMu   sync.Mutex
+	flushCond *sync.Cond
+	pending   []byte
+	closed    bool
+
+	wakeCh    chan struct{}
+	rotateReq chan chan struct{}
+	doneCh    chan struct{}
+	wg        sync.WaitGroup
+
+	file *os.File
+	bw   *bufio.Writer
+	size int64
+}
+
+// NewAsyncRotatingWriter opens (creating if needed) filename for appending
+// and starts the background writer goroutine that rotates it per config.
+func NewAsyncRotatingWriter(filename string, config AsyncRotationConfig) (*AsyncRotatingWriter, error) {
+	config = config.withDefaults()
+
+	if dir := filepath.Dir(filename); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %v", err)
+		}
+	}
+
+	w := &AsyncRotatingWriter{
+		filename:  filename,
+		config:    config,
+		wakeCh:    make(chan struct{}),
+		rotateReq: make(chan chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	w.flushCond = sync.NewCond(&w.flushMu)
+
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+
+	w.wg.Add(2)
+	go w.wakePump()
+	go w.run()
+
+	return w, nil
+}
+
+func (w *AsyncRotatingWriter) openCurrent() error {
+	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %v", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %v", err)
+	}
+
+	w.file = file
+	w.bw = bufio.NewWriter(file)
+	w.size = info.Size()
+	return nil
+}
+
+// Write appends p to the pending buffer and wakes the writer goroutine once
+// FlushSize is exceeded. It never touches the file itself - that's the
+// whole point of handing writes off to a dedicated goroutine.
+func (w *AsyncRotatingWriter) Write(p []byte) (int, error) {
+	w.flushMu.Lock()
+	if w.closed {
+		w.flushMu.Unlock()
+		return 0, fmt.Errorf("async rotating writer: write after close")
+	}
+	w.pending = append(w.pending, p...)
+	w.flushCond.Signal()
+	w.flushMu.Unlock()
+
+	return len(p), nil
+}
+
+// wakePump blocks on flushCond until the pending buffer reaches FlushSize,
+// then relays a wakeup to run's select loop. It's the one piece of this
+// writer that actually waits on the condition variable, so Write's hot path
+// only ever needs a Lock/append/Signal/Unlock instead of a channel send per
+// call.
+func (w *AsyncRotatingWriter) wakePump() {
+	defer w.wg.Done()
+
+	for {
+		w.flushMu.Lock()
+		for len(w.pending) < w.config.FlushSize && !w.closed {
+			w.flushCond.Wait()
+		}
+		closed := w.closed
+		w.flushMu.Unlock()
+
+		if closed {
+			return
+		}
+
+		select {
+		case w.wakeCh <- struct{}{}:
+		case <-w.doneCh:
+			return
+		}
+	}
+}
+
+// run is the sole goroutine that ever touches w.file/w.bw/w.size. It wakes
+// on a FlushSize-triggered wakeCh send, the RotationInterval ticker, an
+// explicit Rotate() request, or Close.
+func (w *AsyncRotatingWriter) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.RotationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.wakeCh:
+			w.flushPending()
+
+		case <-ticker.C:
+			w.flushPending()
+			if err := w.rotate(); err != nil {
+				fmt.Fprintf(os.Stderr, "[WARN] async rotating writer: rotation failed: %v\n", err)
+			}
+
+		case ack := <-w.rotateReq:
+			w.flushPending()
+			if err := w.rotate(); err != nil {
+				fmt.Fprintf(os.Stderr, "[WARN] async rotating writer: rotation failed: %v\n", err)
+			}
+			close(ack)
+
+		case <-w.doneCh:
+			w.flushPending()
+			w.bw.Flush()
+			w.file.Close()
+			return
+		}
+	}
+}
+
+// flushPending drains the pending buffer into the bufio.Writer and flushes
+// it to disk, rotating immediately if that push crossed MaxFileSize.
+func (w *AsyncRotatingWriter) flushPending() {
+	w.flushMu.Lock()
+	data := w.pending
+	w.pending = nil
+	w.flushMu.Unlock()
+
+	if len(data) == 0 {
+		return
+	}
+
+	n, err := w.bw.Write(data)
+	w.size += int64(n)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] async rotating writer: write failed: %v\n", err)
+	}
+	if err := w.bw.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] async rotating writer: flush failed: %v\n", err)
+	}
+
+	if w.config.MaxFileSize > 0 && w.size >= w.config.MaxFileSize {
+		if err := w.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] async rotating writer: rotation failed: %v\n", err)
+		}
+	}
+}
+
+// rotate closes the current file, renames it to a timestamped backup,
+// compresses it asynchronously if configured, prunes old backups, and opens
+// a fresh file at the original path. It no-ops on an empty current file, so
+// the RotationInterval ticker firing right after a size-triggered rotate
+// (or on an idle logger) doesn't rotate an empty file every tick.
+func (w *AsyncRotatingWriter) rotate() error {
+	if w.size == 0 {
+		return nil
+	}
+
+	if err := w.bw.Flush(); err != nil {
+		return fmt.Errorf("failed to flush before rotation: %v", err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file before rotation: %v", err)
+	}
+
+	backupPath := w.backupName()
+	if err := os.Rename(w.filename, backupPath); err != nil {
+		return fmt.Errorf("failed to rotate log file: %v", err)
+	}
+
+	if w.config.Compress {
+		go compressBackup(backupPath)
+	}
+	go pruneBackupFiles(w.filename, 0, w.config.MaxBackups)
+
+	return w.openCurrent()
+}
+
+func (w *AsyncRotatingWriter) backupName() string {
+	ext := filepath.Ext(w.filename)
+	base := strings.TrimSuffix(w.filename, ext)
+	return fmt.Sprintf("%s-%s%s", base, time.Now().Format("20060102-150405"), ext)
+}
+
+// Rotate manually triggers rotation (e.g. on SIGHUP), blocking until the
+// writer goroutine has performed it.
+func (w *AsyncRotatingWriter) Rotate() error {
+	ack := make(chan struct{})
+
+	select {
+	case w.rotateReq <- ack:
+	case <-w.doneCh:
+		return fmt.Errorf("async rotating writer: closed")
+	}
+
+	select {
+	case <-ack:
+		return nil
+	case <-w.doneCh:
+		return nil
+	}
+}
+
+// Close stops the writer goroutines after flushing and closing the
+// underlying file.
+func (w *AsyncRotatingWriter) Close() error {
+	w.flushMu.Lock()
+	if w.closed {
+		w.flushMu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.flushMu.Unlock()
+
+	w.flushCond.Broadcast()
+	close(w.doneCh)
+	w.wg.Wait()
+
+	return nil
+}
+
+var _ io.WriteCloser = (*AsyncRotatingWriter)(nil)
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type ctxKey int
+
+const (
+	loggerCtxKey ctxKey = iota
+	fieldsCtxKey
+)
+
+// NewContext returns a copy of ctx carrying sl as its ambient Logger, so
+// downstream code can retrieve it with FromContext instead of threading it
+// through every function signature.
+func NewContext(ctx context.Context, sl Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, sl)
+}
+
+// FromContext returns the Logger attached to ctx by NewContext, bound to any
+// ambient fields (correlation_id, task_id, proxy_host, session) attached by
+// WithFields, so a single logger.FromContext(ctx).Info(...) carries full
+// contextual metadata with no manual plumbing. Returns a NoopLogger if ctx
+// has no Logger attached.
+func FromContext(ctx context.Context) Logger {
+	base, ok := ctx.Value(loggerCtxKey).(Logger)
+	if !ok {
+		base = NewNoopLogger()
+	}
+
+	fields := AmbientFields(ctx)
+	if len(fields) == 0 {
+		return base
+	}
+	return base.With(fields)
+}
+
+// WithFields returns a copy of ctx with fields merged into its ambient
+// fields, which FromContext (and the *Ctx helper methods below) attach to
+// every subsequent log call automatically.
+func WithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	merged := make(map[string]interface{}, len(fields))
+	for k, v := range AmbientFields(ctx) {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsCtxKey, merged)
+}
+
+// AmbientFields returns the fields attached to ctx by WithFields, or nil if none.
+func AmbientFields(ctx context.Context) map[string]interface{} {
+	fields, _ := ctx.Value(fieldsCtxKey).(map[string]interface{})
+	return fields
+}
+
+// EnsureCorrelationID returns ctx unchanged if it already carries a
+// correlation_id ambient field, or a copy with a freshly generated one
+// attached otherwise. It's meant to sit at the top of a request/task
+// pipeline (e.g. checker.checkCombo) so every downstream log call is
+// correlated even when the caller didn't set one up explicitly.
+func EnsureCorrelationID(ctx context.Context) (context.Context, string) {
+	if fields := AmbientFields(ctx); fields != nil {
+		if id, ok := fields["correlation_id"].(string); ok && id != "" {
+			return ctx, id
+		}
+	}
+
+	id := generateCorrelationID()
+	return WithFields(ctx, map[string]interface{}{"correlation_id": id}), id
+}
+
+// generateCorrelationID returns a short random hex identifier. It's
+// independent of pkg/utils' GenerateCorrelationID so this package has no
+// dependency on the checker's task-ID scheme.
+func generateCorrelationID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "corr-unavailable"
+	}
+	return "corr-" + hex.EncodeToString(buf)
+}
+
+// DebugCtx logs at DEBUG, merging ctx's ambient fields ahead of fields.
+func (sl *StructuredLogger) DebugCtx(ctx context.Context, message string, fields ...map[string]interface{}) {
+	sl.Debug(ctx, message, sl.withAmbient(ctx, fields)...)
+}
+
+// InfoCtx logs at INFO, merging ctx's ambient fields ahead of fields.
+func (sl *StructuredLogger) InfoCtx(ctx context.Context, message string, fields ...map[string]interface{}) {
+	sl.Info(ctx, message, sl.withAmbient(ctx, fields)...)
+}
+
+// WarnCtx logs at WARN, merging ctx's ambient fields ahead of fields.
+func (sl *StructuredLogger) WarnCtx(ctx context.Context, message string, fields ...map[string]interface{}) {
+	sl.Warn(ctx, message, sl.withAmbient(ctx, fields)...)
+}
+
+// ErrorCtx logs at ERROR, merging ctx's ambient fields ahead of fields.
+func (sl *StructuredLogger) ErrorCtx(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	sl.Error(ctx, message, err, sl.withAmbient(ctx, fields)...)
+}
+
+// FatalCtx logs at FATAL, merging ctx's ambient fields ahead of fields.
+func (sl *StructuredLogger) FatalCtx(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	sl.Fatal(ctx, message, err, sl.withAmbient(ctx, fields)...)
+}
+
+func (sl *StructuredLogger) withAmbient(ctx context.Context, fields []map[string]interface{}) []map[string]interface{} {
+	ambient := AmbientFields(ctx)
+	if len(ambient) == 0 {
+		return fields
+	}
+	return append([]map[string]interface{}{ambient}, fields...)
+}
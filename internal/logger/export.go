@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat selects how ExportLogs serializes the exported LogEntry values.
+type ExportFormat int
+
+const (
+	// FormatJSONArray wraps the entries in a single JSON object/array, matching
+	// ExportLogs' original on-disk shape.
+	FormatJSONArray ExportFormat = iota
+	// FormatNDJSON writes one JSON object per line (newline-delimited JSON),
+	// the format jq and most log shippers expect.
+	FormatNDJSON
+	// FormatCSV writes a flattened CSV with one row per entry; the Fields map
+	// is serialized as a JSON blob in its own column since CSV has no nested
+	// structure.
+	FormatCSV
+)
+
+// ExportOptions configures ExportLogs.
+type ExportOptions struct {
+	Format ExportFormat
+	// Limit caps how many of the most recent buffered entries are exported;
+	// 0 or negative exports everything currently buffered.
+	Limit int
+	// Gzip compresses the written output regardless of format. ExportLogsToFile
+	// also enables this automatically when filename ends in ".gz".
+	Gzip bool
+}
+
+// ExportLogs streams recent buffered log entries to w in opts.Format,
+// optionally gzip-compressed. Unlike the old fmt.Fprintf-based exporter, this
+// goes through encoding/json (or encoding/csv), so messages and errors
+// containing quotes, backslashes, or control characters can't corrupt the
+// output.
+func (sl *StructuredLogger) ExportLogs(ctx context.Context, w io.Writer, opts ExportOptions) error {
+	logs := sl.GetRecentLogs(opts.Limit)
+
+	if opts.Gzip {
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		w = gz
+	}
+
+	switch opts.Format {
+	case FormatNDJSON:
+		return sl.exportNDJSON(w, logs)
+	case FormatCSV:
+		return sl.exportCSV(w, logs)
+	default:
+		return sl.exportJSONArray(w, logs)
+	}
+}
+
+// ExportLogsToFile is a convenience wrapper around ExportLogs that creates
+// (or truncates) filename and enables gzip automatically when it ends in
+// ".gz".
+func (sl *StructuredLogger) ExportLogsToFile(ctx context.Context, filename string, opts ExportOptions) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %v", err)
+	}
+	defer file.Close()
+
+	if strings.HasSuffix(filename, ".gz") {
+		opts.Gzip = true
+	}
+
+	return sl.ExportLogs(ctx, file, opts)
+}
+
+func (sl *StructuredLogger) exportJSONArray(w io.Writer, logs []LogEntry) error {
+	enc := json.NewEncoder(w)
+
+	type exportDocument struct {
+		ExportedAt time.Time  `json:"exported_at"`
+		SessionID  string     `json:"session_id"`
+		TotalLogs  int        `json:"total_logs"`
+		Logs       []LogEntry `json:"logs"`
+	}
+
+	return enc.Encode(exportDocument{
+		ExportedAt: time.Now(),
+		SessionID:  sl.sessionID,
+		TotalLogs:  len(logs),
+		Logs:       logs,
+	})
+}
+
+func (sl *StructuredLogger) exportNDJSON(w io.Writer, logs []LogEntry) error {
+	enc := json.NewEncoder(w)
+	for _, entry := range logs {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var csvHeader = []string{
+	"timestamp", "level", "message", "component", "session",
+	"correlation_id", "task_id", "proxy_host", "proxy_port",
+	"latency", "status_code", "retry_attempt", "error", "fields",
+}
+
+func (sl *StructuredLogger) exportCSV(w io.Writer, logs []LogEntry) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, entry := range logs {
+		fieldsJSON := ""
+		if len(entry.Fields) > 0 {
+			encoded, err := json.Marshal(entry.Fields)
+			if err != nil {
+				return err
+			}
+			fieldsJSON = string(encoded)
+		}
+
+		row := []string{
+			entry.Timestamp.Format(time.RFC3339),
+			entry.Level,
+			entry.Message,
+			entry.Component,
+			entry.Session,
+			entry.CorrelationID,
+			entry.TaskID,
+			entry.ProxyHost,
+			strconv.Itoa(entry.ProxyPort),
+			strconv.Itoa(entry.Latency),
+			strconv.Itoa(entry.StatusCode),
+			strconv.Itoa(entry.RetryAttempt),
+			entry.Error,
+			fieldsJSON,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
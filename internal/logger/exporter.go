@@ -0,0 +1,291 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LogExporter is a pluggable sink that StructuredLogger fans log entries out
+// to in addition to its own internal buffer, e.g. a file, stdout, or a
+// remote collector.
+type LogExporter interface {
+	// Export delivers a batch of entries. Implementations should not block
+	// the caller for longer than it takes to enqueue the work.
+	Export(ctx context.Context, entries []LogEntry) error
+	// Flush drains any buffered/in-flight work before returning.
+	Flush(ctx context.Context) error
+}
+
+// OTLPExporterConfig configures an OTLPExporter.
+type OTLPExporterConfig struct {
+	// Endpoint is the OTLP/HTTP logs endpoint, e.g. "http://collector:4318/v1/logs".
+	Endpoint string
+	Headers  map[string]string
+
+	BatchSize      int
+	FlushInterval  time.Duration
+	MaxQueueLength int
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+}
+
+// DefaultOTLPExporterConfig returns sane defaults for OTLPExporterConfig.
+func DefaultOTLPExporterConfig(endpoint string) OTLPExporterConfig {
+	return OTLPExporterConfig{
+		Endpoint:       endpoint,
+		BatchSize:      100,
+		FlushInterval:  5 * time.Second,
+		MaxQueueLength: 10000,
+		MaxRetries:     3,
+		RetryBaseDelay: 500 * time.Millisecond,
+	}
+}
+
+// OTLPExporter batches LogEntry records and ships them to an OTLP/HTTP
+// collector in a background goroutine, retrying with exponential backoff
+// and dropping (with a counter) once the queue is saturated.
+type OTLPExporter struct {
+	config OTLPExporterConfig
+	client *http.Client
+
+	queue   chan LogEntry
+	dropped uint64
+
+	flushReq  chan chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewOTLPExporter creates and starts an OTLPExporter's background shipper.
+func NewOTLPExporter(config OTLPExporterConfig) *OTLPExporter {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.MaxQueueLength <= 0 {
+		config.MaxQueueLength = 10000
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryBaseDelay <= 0 {
+		config.RetryBaseDelay = 500 * time.Millisecond
+	}
+
+	exp := &OTLPExporter{
+		config:   config,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		queue:    make(chan LogEntry, config.MaxQueueLength),
+		flushReq: make(chan chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	exp.wg.Add(1)
+	go exp.run()
+
+	return exp
+}
+
+// Export enqueues entries for delivery, dropping (and counting) any that
+// don't fit once the queue is saturated rather than blocking the logger.
+func (e *OTLPExporter) Export(ctx context.Context, entries []LogEntry) error {
+	for _, entry := range entries {
+		select {
+		case e.queue <- entry:
+		default:
+			atomic.AddUint64(&e.dropped, 1)
+		}
+	}
+	return nil
+}
+
+// DroppedCount returns how many entries have been dropped due to a saturated queue.
+func (e *OTLPExporter) DroppedCount() uint64 {
+	return atomic.LoadUint64(&e.dropped)
+}
+
+// Flush blocks until every batch buffered before the call has been shipped
+// (or has exhausted its retries).
+func (e *OTLPExporter) Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case e.flushReq <- ack:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-e.done:
+		return nil
+	}
+
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the background shipper after flushing any pending batch.
+func (e *OTLPExporter) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.done)
+	})
+	e.wg.Wait()
+	return nil
+}
+
+func (e *OTLPExporter) run() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]LogEntry, 0, e.config.BatchSize)
+
+	shipAndReset := func() {
+		if len(batch) == 0 {
+			return
+		}
+		e.shipWithRetry(batch)
+		batch = make([]LogEntry, 0, e.config.BatchSize)
+	}
+
+	for {
+		select {
+		case entry := <-e.queue:
+			batch = append(batch, entry)
+			if len(batch) >= e.config.BatchSize {
+				shipAndReset()
+			}
+		case <-ticker.C:
+			shipAndReset()
+		case ack := <-e.flushReq:
+			e.drainQueue(&batch)
+			shipAndReset()
+			close(ack)
+		case <-e.done:
+			e.drainQueue(&batch)
+			shipAndReset()
+			return
+		}
+	}
+}
+
+// drainQueue pulls any entries currently sitting in the channel into batch
+// without blocking, used before a Flush/Close-triggered ship.
+func (e *OTLPExporter) drainQueue(batch *[]LogEntry) {
+	for {
+		select {
+		case entry := <-e.queue:
+			*batch = append(*batch, entry)
+		default:
+			return
+		}
+	}
+}
+
+// shipWithRetry POSTs a batch to the OTLP endpoint, retrying with
+// exponential backoff up to MaxRetries times before giving up on the batch.
+func (e *OTLPExporter) shipWithRetry(batch []LogEntry) {
+	payload, err := json.Marshal(otlpLogsPayload(batch))
+	if err != nil {
+		return
+	}
+
+	delay := e.config.RetryBaseDelay
+	for attempt := 0; attempt <= e.config.MaxRetries; attempt++ {
+		if e.send(payload) {
+			return
+		}
+		if attempt < e.config.MaxRetries {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+}
+
+func (e *OTLPExporter) send(payload []byte) bool {
+	req, err := http.NewRequest(http.MethodPost, e.config.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// otlpLogRecord maps a LogEntry onto the standard OTel log record shape so
+// its correlation_id/task_id/proxy fields line up with attributes a
+// collector would also see on the corresponding trace.
+type otlpLogRecord struct {
+	TimeUnixNano int64                  `json:"timeUnixNano"`
+	SeverityText string                 `json:"severityText"`
+	Body         string                 `json:"body"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+}
+
+func otlpLogsPayload(batch []LogEntry) map[string]interface{} {
+	records := make([]otlpLogRecord, 0, len(batch))
+	for _, entry := range batch {
+		attrs := map[string]interface{}{}
+		if entry.CorrelationID != "" {
+			attrs["correlation_id"] = entry.CorrelationID
+		}
+		if entry.TaskID != "" {
+			attrs["task_id"] = entry.TaskID
+		}
+		if entry.ProxyHost != "" {
+			attrs["proxy_host"] = entry.ProxyHost
+			attrs["proxy_port"] = entry.ProxyPort
+		}
+		if entry.Component != "" {
+			attrs["component"] = entry.Component
+		}
+		if entry.Error != "" {
+			attrs["error"] = entry.Error
+		}
+		for k, v := range entry.Fields {
+			attrs[k] = v
+		}
+
+		records = append(records, otlpLogRecord{
+			TimeUnixNano: entry.Timestamp.UnixNano(),
+			SeverityText: entry.Level,
+			Body:         entry.Message,
+			Attributes:   attrs,
+		})
+	}
+
+	return map[string]interface{}{
+		"resourceLogs": []map[string]interface{}{
+			{
+				"scopeLogs": []map[string]interface{}{
+					{"logRecords": records},
+				},
+			},
+		},
+	}
+}
+
+// String returns a readable identity for the exporter, useful in logs about
+// the logger's own registered sinks.
+func (e *OTLPExporter) String() string {
+	return fmt.Sprintf("OTLPExporter(%s)", e.config.Endpoint)
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// FacilityInfo is a point-in-time snapshot of a registered debug facility,
+// returned by Facilities() for the checker's GET /debug/facilities endpoint.
+type FacilityInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// facility is the mutable state backing a single FacilityInfo. enabled is an
+// atomic.Bool rather than a mutex-guarded field, so SetFacility flips it and
+// ShouldDebug - the fast path every guarded Debugf call goes through - reads
+// it outside the registry lock; that lock is held only for the map lookup.
+type facility struct {
+	description string
+	enabled     atomic.Bool
+}
+
+var (
+	facilitiesMu sync.RWMutex
+	facilities   = make(map[string]*facility)
+)
+
+// RegisterFacility registers name as a debug facility with description,
+// defaulting to disabled. Calling it again for an already-registered name
+// updates the description without touching its current enabled state, so
+// package init functions can call it unconditionally.
+func RegisterFacility(name, description string) {
+	facilitiesMu.Lock()
+	defer facilitiesMu.Unlock()
+
+	f, ok := facilities[name]
+	if !ok {
+		f = &facility{}
+		facilities[name] = f
+	}
+	f.description = description
+}
+
+// SetFacility enables or disables a facility by name. An unregistered name
+// is registered on the fly with an empty description, so a mistyped name in
+// a POST /debug/facilities request doesn't just silently no-op.
+func SetFacility(name string, enabled bool) {
+	facilitiesMu.Lock()
+	f, ok := facilities[name]
+	if !ok {
+		f = &facility{}
+		facilities[name] = f
+	}
+	facilitiesMu.Unlock()
+
+	f.enabled.Store(enabled)
+}
+
+// ShouldDebug reports whether facility is currently enabled. It's the fast
+// path callers should guard expensive argument construction behind - a hex
+// dump of a combo/proxy payload, say - before ever building the message.
+func ShouldDebug(facility string) bool {
+	facilitiesMu.RLock()
+	f, ok := facilities[facility]
+	facilitiesMu.RUnlock()
+
+	return ok && f.enabled.Load()
+}
+
+// Facilities returns a snapshot of every registered facility and its current
+// status, sorted by name for a stable JSON response.
+func Facilities() []FacilityInfo {
+	facilitiesMu.RLock()
+	defer facilitiesMu.RUnlock()
+
+	result := make([]FacilityInfo, 0, len(facilities))
+	for name, f := range facilities {
+		result = append(result, FacilityInfo{
+			Name:        name,
+			Description: f.description,
+			Enabled:     f.enabled.Load(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Debugf logs a printf-formatted debug message for facility against ctx's
+// ambient Logger, doing nothing beyond a map lookup and an atomic load if
+// the facility isn't enabled - cheap enough to leave sprinkled through a hot
+// path like checkCombo without a build tag or a wrapping if statement.
+func Debugf(ctx context.Context, facility string, format string, args ...interface{}) {
+	if !ShouldDebug(facility) {
+		return
+	}
+	FromContext(ctx).Debug(ctx, fmt.Sprintf(format, args...), map[string]interface{}{"facility": facility})
+}
@@ -0,0 +1,125 @@
+package logger
+
+import "context"
+
+// Logger is the leveled, structured logging surface every backend in this
+// package implements. Call sites should depend on Logger rather than the
+// concrete *StructuredLogger so the backend (slog, zap, zerolog, noop) can be
+// swapped via LoggerConfig.Backend without touching them.
+type Logger interface {
+	Debug(ctx context.Context, message string, fields ...map[string]interface{})
+	Info(ctx context.Context, message string, fields ...map[string]interface{})
+	Warn(ctx context.Context, message string, fields ...map[string]interface{})
+	Error(ctx context.Context, message string, err error, fields ...map[string]interface{})
+	Fatal(ctx context.Context, message string, err error, fields ...map[string]interface{})
+
+	// V reports whether level would actually be emitted, letting callers
+	// skip building an expensive field map for a level that's discarded.
+	V(level LogLevel) bool
+
+	// With returns a Logger that merges base into every field map passed to
+	// its future calls, without mutating the receiver.
+	With(base map[string]interface{}) Logger
+
+	// Flush drains any buffered/in-flight work (e.g. registered exporters).
+	Flush(ctx context.Context) error
+}
+
+// Backend selects which Logger implementation NewLogger constructs.
+type Backend string
+
+const (
+	// BackendSlog is the default, backed by StructuredLogger's log/slog handler.
+	BackendSlog Backend = "slog"
+	// BackendZap is backed by go.uber.org/zap's SugaredLogger.
+	BackendZap Backend = "zap"
+	// BackendZerolog is backed by rs/zerolog.
+	BackendZerolog Backend = "zerolog"
+	// BackendNoop discards everything; useful for tests and benchmarks that
+	// don't want logging overhead or output noise.
+	BackendNoop Backend = "noop"
+)
+
+// NewLogger constructs a Logger for config.Backend, defaulting to BackendSlog
+// when unset.
+func NewLogger(config LoggerConfig) (Logger, error) {
+	switch config.Backend {
+	case "", BackendSlog:
+		return NewStructuredLogger(config)
+	case BackendZap:
+		return newZapLogger(config)
+	case BackendZerolog:
+		return newZerologLogger(config)
+	case BackendNoop:
+		return NewNoopLogger(), nil
+	default:
+		return nil, unknownBackendError(config.Backend)
+	}
+}
+
+func unknownBackendError(b Backend) error {
+	return &UnknownBackendError{Backend: b}
+}
+
+// UnknownBackendError is returned by NewLogger for an unrecognized Backend.
+type UnknownBackendError struct {
+	Backend Backend
+}
+
+func (e *UnknownBackendError) Error() string {
+	return "logger: unknown backend " + string(e.Backend)
+}
+
+// boundLogger is the Logger returned by StructuredLogger.With: it merges a
+// fixed set of base fields into every call before delegating to base.
+type boundLogger struct {
+	base   *StructuredLogger
+	fields map[string]interface{}
+}
+
+func (b *boundLogger) merge(fields []map[string]interface{}) []map[string]interface{} {
+	return append([]map[string]interface{}{b.fields}, fields...)
+}
+
+func (b *boundLogger) Debug(ctx context.Context, message string, fields ...map[string]interface{}) {
+	b.base.Debug(ctx, message, b.merge(fields)...)
+}
+
+func (b *boundLogger) Info(ctx context.Context, message string, fields ...map[string]interface{}) {
+	b.base.Info(ctx, message, b.merge(fields)...)
+}
+
+func (b *boundLogger) Warn(ctx context.Context, message string, fields ...map[string]interface{}) {
+	b.base.Warn(ctx, message, b.merge(fields)...)
+}
+
+func (b *boundLogger) Error(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	b.base.Error(ctx, message, err, b.merge(fields)...)
+}
+
+func (b *boundLogger) Fatal(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
+	b.base.Fatal(ctx, message, err, b.merge(fields)...)
+}
+
+func (b *boundLogger) V(level LogLevel) bool {
+	return b.base.V(level)
+}
+
+func (b *boundLogger) With(base map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(b.fields)+len(base))
+	for k, v := range b.fields {
+		merged[k] = v
+	}
+	for k, v := range base {
+		merged[k] = v
+	}
+	return &boundLogger{base: b.base, fields: merged}
+}
+
+func (b *boundLogger) Flush(ctx context.Context) error {
+	return b.base.Flush(ctx)
+}
+
+// Ensure the two slog-backed implementations satisfy Logger.
+var _ Logger = (*StructuredLogger)(nil)
+var _ Logger = (*boundLogger)(nil)
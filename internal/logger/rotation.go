@@ -0,0 +1,250 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationConfig controls when and how a RotatingWriter rotates its backing
+// file. A zero value disables rotation entirely.
+type RotationConfig struct {
+	// MaxSizeMB rotates the file once it would exceed this size. 0 disables
+	// size-based rotation.
+	MaxSizeMB int
+	// MaxAge deletes rotated backups older than this once they've been on
+	// disk for longer. 0 disables age-based pruning.
+	MaxAge time.Duration
+	// MaxBackups caps how many rotated backups are kept; the oldest are
+	// deleted once the count is exceeded. 0 means unlimited.
+	MaxBackups int
+	// Compress gzips rotated backups asynchronously after rotation.
+	Compress bool
+	// DailyRotation rotates the file at the first write after local
+	// midnight, in addition to any size-based rotation.
+	DailyRotation bool
+}
+
+// RotatingWriter is an io.WriteCloser backing a log file that rotates by size
+// and/or daily, gzipping old backups and pruning the oldest once MaxBackups
+// is exceeded. It is safe for concurrent Write calls from both the slog
+// handler and a io.MultiWriter in JSON mode.
+type RotatingWriter struct {
+	mu sync.Mutex
+
+	filename string
+	config   RotationConfig
+
+	file      *os.File
+	size      int64
+	openedDay int // day-of-year the current file was opened, for DailyRotation
+}
+
+// NewRotatingWriter opens (creating if needed) filename for appending and
+// returns a RotatingWriter that rotates it per config.
+func NewRotatingWriter(filename string, config RotationConfig) (*RotatingWriter, error) {
+	if dir := filepath.Dir(filename); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %v", err)
+		}
+	}
+
+	w := &RotatingWriter{filename: filename, config: config}
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotatingWriter) openCurrent() error {
+	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %v", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %v", err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedDay = time.Now().YearDay()
+	return nil
+}
+
+// Write implements io.Writer, rotating first if p would push the file past
+// MaxSizeMB or the day has rolled over under DailyRotation.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotateLocked(len(p)) {
+		if err := w.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *RotatingWriter) shouldRotateLocked(nextWriteLen int) bool {
+	if w.config.MaxSizeMB > 0 && w.size+int64(nextWriteLen) > int64(w.config.MaxSizeMB)*1024*1024 {
+		return true
+	}
+	if w.config.DailyRotation && time.Now().YearDay() != w.openedDay {
+		return true
+	}
+	return false
+}
+
+// Rotate closes the current file, renames it to a timestamped backup,
+// compresses it asynchronously if configured, prunes old backups, and opens
+// a fresh file at the original path. It can be called manually (e.g. on
+// SIGHUP) in addition to the automatic triggers in Write.
+func (w *RotatingWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotateLocked()
+}
+
+func (w *RotatingWriter) rotateLocked() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file before rotation: %v", err)
+	}
+
+	backupPath := w.backupName()
+	if err := os.Rename(w.filename, backupPath); err != nil {
+		return fmt.Errorf("failed to rotate log file: %v", err)
+	}
+
+	if w.config.Compress {
+		go compressBackup(backupPath)
+	}
+	go w.pruneBackups()
+
+	return w.openCurrent()
+}
+
+func (w *RotatingWriter) backupName() string {
+	ext := filepath.Ext(w.filename)
+	base := strings.TrimSuffix(w.filename, ext)
+	return fmt.Sprintf("%s-%s%s", base, time.Now().Format("20060102-150405"), ext)
+}
+
+// compressBackup gzips a rotated backup file in place and removes the
+// uncompressed original, run in its own goroutine so rotation isn't blocked
+// on I/O for a (by then) inactive file.
+func compressBackup(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		return
+	}
+
+	os.Remove(path)
+}
+
+// pruneBackups deletes rotated backups beyond MaxBackups (oldest first) and
+// any older than MaxAge, run asynchronously since it only touches files that
+// are no longer being written to.
+func (w *RotatingWriter) pruneBackups() {
+	pruneBackupFiles(w.filename, w.config.MaxAge, w.config.MaxBackups)
+}
+
+// pruneBackupFiles deletes rotated backups of filename (matching the
+// "<base>-<suffix><ext>" and "<base>-<suffix><ext>.gz" naming both
+// RotatingWriter and AsyncRotatingWriter rotate to) beyond maxBackups
+// (oldest first) and any older than maxAge. maxAge of 0 disables age-based
+// pruning. It's safe to call from a background goroutine since it only
+// touches files no longer being written to.
+func pruneBackupFiles(filename string, maxAge time.Duration, maxBackups int) {
+	ext := filepath.Ext(filename)
+	base := strings.TrimSuffix(filepath.Base(filename), ext)
+	dir := filepath.Dir(filename)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	var backups []backup
+
+	prefix := base + "-"
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if !strings.HasSuffix(name, ext) && !strings.HasSuffix(name, ext+".gz") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, name), modTime: info.ModTime()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].modTime.Before(backups[j].modTime) })
+
+	if maxAge > 0 {
+		cutoff := time.Now().Add(-maxAge)
+		kept := backups[:0]
+		for _, b := range backups {
+			if b.modTime.Before(cutoff) {
+				os.Remove(b.path)
+				continue
+			}
+			kept = append(kept, b)
+		}
+		backups = kept
+	}
+
+	if maxBackups > 0 && len(backups) > maxBackups {
+		excess := len(backups) - maxBackups
+		for _, b := range backups[:excess] {
+			os.Remove(b.path)
+		}
+	}
+}
+
+// Close closes the current underlying file.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+var _ io.WriteCloser = (*RotatingWriter)(nil)
@@ -0,0 +1,202 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LevelSamplingConfig configures token-bucket sampling for a single log
+// level: the first Burst records within Interval are always logged; once the
+// burst is exhausted, only 1 in SampleRate records are let through until the
+// window rolls over.
+type LevelSamplingConfig struct {
+	Burst      int
+	Interval   time.Duration
+	SampleRate int
+}
+
+// SamplingConfig configures a SamplingHandler.
+type SamplingConfig struct {
+	// Levels maps a LogLevel to its sampling rule. A level with no entry is
+	// never sampled (always logged).
+	Levels map[LogLevel]LevelSamplingConfig
+}
+
+// DefaultSamplingConfig returns sampling tuned for a busy checker run: INFO
+// and DEBUG are aggressively sampled per-key, WARN is lightly sampled, and
+// ERROR/FATAL are left untouched so failures are never silently dropped.
+func DefaultSamplingConfig() SamplingConfig {
+	return SamplingConfig{
+		Levels: map[LogLevel]LevelSamplingConfig{
+			DEBUG: {Burst: 10, Interval: time.Second, SampleRate: 100},
+			INFO:  {Burst: 20, Interval: time.Second, SampleRate: 50},
+			WARN:  {Burst: 20, Interval: time.Second, SampleRate: 5},
+		},
+	}
+}
+
+// bucketState is the per-(level, key) token-bucket state.
+type bucketState struct {
+	windowStart time.Time
+	inWindow    int64
+}
+
+// samplingState is the token-bucket/drop-counter state shared by a
+// SamplingHandler and every wrapper WithAttrs/WithGroup derives from it, so
+// budgets stay correct across slog's handler-chaining pattern.
+type samplingState struct {
+	mu      sync.Mutex
+	buckets map[string]*bucketState
+
+	dropped   uint64
+	droppedBy sync.Map // key -> *uint64
+}
+
+// SamplingHandler wraps a slog.Handler and applies per-level token-bucket
+// sampling keyed on (component, event_type, status_code), so a single
+// high-frequency event type or a single failing proxy can't monopolize the
+// level's budget and drown out everything else.
+type SamplingHandler struct {
+	inner     slog.Handler
+	config    SamplingConfig
+	component string
+	state     *samplingState
+}
+
+// NewSamplingHandler wraps inner with per-level, per-key token-bucket sampling.
+func NewSamplingHandler(inner slog.Handler, config SamplingConfig, component string) *SamplingHandler {
+	return &SamplingHandler{
+		inner:     inner,
+		config:    config,
+		component: component,
+		state:     &samplingState{buckets: make(map[string]*bucketState)},
+	}
+}
+
+// Enabled delegates to the wrapped handler.
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle applies sampling before delegating to the wrapped handler.
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	rule, sampled := h.config.Levels[levelFromSlog(r.Level)]
+	if !sampled {
+		return h.inner.Handle(ctx, r)
+	}
+
+	key := h.sampleKey(r)
+	if !h.allow(levelFromSlog(r.Level), key, rule) {
+		h.recordDrop(key)
+		return nil
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+// sampleKey extracts (component, event_type, status_code) from the record's
+// attrs so each combination gets its own budget.
+func (h *SamplingHandler) sampleKey(r slog.Record) string {
+	eventType, statusCode := "", ""
+	r.Attrs(func(a slog.Attr) bool {
+		switch a.Key {
+		case "event_type":
+			eventType = a.Value.String()
+		case "status_code":
+			statusCode = a.Value.String()
+		}
+		return true
+	})
+	return fmt.Sprintf("%s|%s|%s", h.component, eventType, statusCode)
+}
+
+func (h *SamplingHandler) allow(level LogLevel, key string, rule LevelSamplingConfig) bool {
+	bucketKey := fmt.Sprintf("%d|%s", level, key)
+
+	h.state.mu.Lock()
+	defer h.state.mu.Unlock()
+
+	state, ok := h.state.buckets[bucketKey]
+	now := time.Now()
+	if !ok || now.Sub(state.windowStart) >= rule.Interval {
+		state = &bucketState{windowStart: now}
+		h.state.buckets[bucketKey] = state
+	}
+
+	state.inWindow++
+	if state.inWindow <= int64(rule.Burst) {
+		return true
+	}
+
+	sampleRate := rule.SampleRate
+	if sampleRate <= 0 {
+		sampleRate = 1
+	}
+	return (state.inWindow-int64(rule.Burst))%int64(sampleRate) == 0
+}
+
+func (h *SamplingHandler) recordDrop(key string) {
+	atomic.AddUint64(&h.state.dropped, 1)
+
+	counter, _ := h.state.droppedBy.LoadOrStore(key, new(uint64))
+	atomic.AddUint64(counter.(*uint64), 1)
+}
+
+// DroppedCount returns the total number of records suppressed by sampling.
+func (h *SamplingHandler) DroppedCount() uint64 {
+	return atomic.LoadUint64(&h.state.dropped)
+}
+
+// DroppedByKey returns a snapshot of suppressed counts per (component,
+// event_type, status_code) key, suitable for a periodic "N logs suppressed"
+// summary line.
+func (h *SamplingHandler) DroppedByKey() map[string]uint64 {
+	snapshot := make(map[string]uint64)
+	h.state.droppedBy.Range(func(k, v interface{}) bool {
+		snapshot[k.(string)] = atomic.LoadUint64(v.(*uint64))
+		return true
+	})
+	return snapshot
+}
+
+// WithAttrs returns a new SamplingHandler wrapping the inner handler's
+// WithAttrs result, sharing sampling state with the handler it was derived from.
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{
+		inner:     h.inner.WithAttrs(attrs),
+		config:    h.config,
+		component: h.component,
+		state:     h.state,
+	}
+}
+
+// WithGroup returns a new SamplingHandler wrapping the inner handler's
+// WithGroup result, sharing sampling state with the handler it was derived from.
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{
+		inner:     h.inner.WithGroup(name),
+		config:    h.config,
+		component: h.component,
+		state:     h.state,
+	}
+}
+
+func levelFromSlog(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARN
+	case level < slog.LevelError+1:
+		return ERROR
+	default:
+		return FATAL
+	}
+}
+
+var _ slog.Handler = (*SamplingHandler)(nil)
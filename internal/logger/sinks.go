@@ -0,0 +1,459 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SinkType identifies a LoggerConfig.Sinks entry's destination, mirroring
+// the sink catalogs of ecosystems like hclog/logrus.
+type SinkType string
+
+const (
+	SinkFile              SinkType = "file"
+	SinkRotatingFile      SinkType = "rotating_file"
+	SinkAsyncRotatingFile SinkType = "async_rotating_file"
+	SinkStdout            SinkType = "stdout"
+	SinkStderr            SinkType = "stderr"
+	SinkSyslog            SinkType = "syslog"
+	SinkWebhook           SinkType = "webhook"
+	SinkWebSocket         SinkType = "websocket"
+)
+
+// SinkFormat selects how a sink renders a LogEntry to bytes.
+type SinkFormat string
+
+const (
+	// SinkFormatJSON marshals the LogEntry as-is.
+	SinkFormatJSON SinkFormat = "json"
+	// SinkFormatLogfmt renders key=value pairs, one per line, for a human
+	// tailing the console.
+	SinkFormatLogfmt SinkFormat = "logfmt"
+)
+
+// WebSocketBroadcaster is the minimal surface a SinkWebSocket needs. Any type
+// with a BroadcastLog method satisfies it, so this package never has to
+// import streamserver. streamserver.Server has no BroadcastLog method; it
+// feeds /ws/logs through the LogExporter its LogSink method returns.
+type WebSocketBroadcaster interface {
+	BroadcastLog(payload []byte)
+}
+
+// SinkConfig describes one destination in LoggerConfig.Sinks: its Type, its
+// own Level floor and Format, and whichever of the type-specific fields
+// below that Type actually reads. This lets an operator pair a human-readable
+// console sink with a JSON file sink and a webhook that only fires on ERROR.
+type SinkConfig struct {
+	Type   SinkType   `json:"type"`
+	Level  LogLevel   `json:"level"`
+	Format SinkFormat `json:"format"`
+
+	// Path is the destination file for SinkFile/SinkRotatingFile.
+	Path string `json:"path,omitempty"`
+	// Rotation configures SinkRotatingFile; ignored for every other Type.
+	Rotation RotationConfig `json:"rotation,omitempty"`
+	// AsyncRotation configures SinkAsyncRotatingFile; ignored for every
+	// other Type.
+	AsyncRotation AsyncRotationConfig `json:"async_rotation,omitempty"`
+
+	// SyslogNetwork/SyslogAddr select a remote syslog daemon for SinkSyslog;
+	// leave both empty to dial the local daemon via log/syslog's defaults.
+	SyslogNetwork string `json:"syslog_network,omitempty"`
+	SyslogAddr    string `json:"syslog_addr,omitempty"`
+	SyslogTag     string `json:"syslog_tag,omitempty"`
+
+	// Webhook* configure SinkWebhook, POSTing each entry as JSON.
+	WebhookURL        string            `json:"webhook_url,omitempty"`
+	WebhookHeaders    map[string]string `json:"webhook_headers,omitempty"`
+	WebhookTimeout    time.Duration     `json:"webhook_timeout,omitempty"`
+	WebhookMaxRetries int               `json:"webhook_max_retries,omitempty"`
+
+	// Broadcaster backs SinkWebSocket; the checker wires this to its stream
+	// server after construction, since LoggerConfig itself is built before
+	// the stream server exists.
+	Broadcaster WebSocketBroadcaster `json:"-"`
+}
+
+// filteredSink pairs a rendering Format and Level floor with whatever does
+// the actual writing, so every sink type shares the same filter/format logic.
+type filteredSink struct {
+	level  LogLevel
+	format SinkFormat
+	write  func([]byte) error
+	close  func() error
+}
+
+func (s *filteredSink) deliver(entry LogEntry) error {
+	if levelFromString(entry.Level) < s.level {
+		return nil
+	}
+	line, err := renderEntry(s.format, entry)
+	if err != nil {
+		return err
+	}
+	return s.write(line)
+}
+
+// buildSink constructs the filteredSink for one SinkConfig entry.
+func buildSink(cfg SinkConfig) (*filteredSink, error) {
+	format := cfg.Format
+	if format == "" {
+		format = SinkFormatJSON
+	}
+
+	sink := &filteredSink{level: cfg.Level, format: format}
+
+	switch cfg.Type {
+	case SinkFile:
+		file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("sink %s: failed to open %s: %v", cfg.Type, cfg.Path, err)
+		}
+		sink.write = writerFunc(file)
+		sink.close = file.Close
+
+	case SinkRotatingFile:
+		rotator, err := NewRotatingWriter(cfg.Path, cfg.Rotation)
+		if err != nil {
+			return nil, fmt.Errorf("sink %s: %v", cfg.Type, err)
+		}
+		sink.write = writerFunc(rotator)
+		sink.close = rotator.Close
+
+	case SinkAsyncRotatingFile:
+		rotator, err := NewAsyncRotatingWriter(cfg.Path, cfg.AsyncRotation)
+		if err != nil {
+			return nil, fmt.Errorf("sink %s: %v", cfg.Type, err)
+		}
+		sink.write = writerFunc(rotator)
+		sink.close = rotator.Close
+
+	case SinkStdout:
+		sink.write = writerFunc(os.Stdout)
+
+	case SinkStderr:
+		sink.write = writerFunc(os.Stderr)
+
+	case SinkSyslog:
+		writer, err := syslog.Dial(cfg.SyslogNetwork, cfg.SyslogAddr, syslog.LOG_INFO, cfg.SyslogTag)
+		if err != nil {
+			return nil, fmt.Errorf("sink %s: failed to dial syslog: %v", cfg.Type, err)
+		}
+		sink.write = syslogWriteFunc(writer)
+		sink.close = writer.Close
+
+	case SinkWebhook:
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("sink %s: webhook_url is required", cfg.Type)
+		}
+		sink.write = newWebhookSink(cfg).write
+
+	case SinkWebSocket:
+		if cfg.Broadcaster == nil {
+			return nil, fmt.Errorf("sink %s: broadcaster is required", cfg.Type)
+		}
+		broadcaster := cfg.Broadcaster
+		sink.write = func(line []byte) error {
+			broadcaster.BroadcastLog(line)
+			return nil
+		}
+
+	default:
+		return nil, fmt.Errorf("logger: unknown sink type %q", cfg.Type)
+	}
+
+	return sink, nil
+}
+
+func writerFunc(w io.Writer) func([]byte) error {
+	return func(line []byte) error {
+		_, err := w.Write(line)
+		return err
+	}
+}
+
+// syslogWriteFunc writes every entry through log/syslog.Writer's Info
+// method, so all entries go out at INFO priority; an entry's own level is
+// not mapped to a syslog severity.
+func syslogWriteFunc(w *syslog.Writer) func([]byte) error {
+	return func(line []byte) error {
+		return w.Info(string(line))
+	}
+}
+
+// webhookSink POSTs each delivered line as the body of a JSON request,
+// retrying with exponential backoff like OTLPExporter does for batches.
+type webhookSink struct {
+	url        string
+	headers    map[string]string
+	client     *http.Client
+	maxRetries int
+}
+
+func newWebhookSink(cfg SinkConfig) *webhookSink {
+	timeout := cfg.WebhookTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	maxRetries := cfg.WebhookMaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+	return &webhookSink{
+		url:        cfg.WebhookURL,
+		headers:    cfg.WebhookHeaders,
+		client:     &http.Client{Timeout: timeout},
+		maxRetries: maxRetries,
+	}
+}
+
+func (s *webhookSink) write(line []byte) error {
+	delay := 500 * time.Millisecond
+	var lastErr error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if err := s.post(line); err != nil {
+			lastErr = err
+			if attempt < s.maxRetries {
+				time.Sleep(delay)
+				delay *= 2
+			}
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+func (s *webhookSink) post(line []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(line))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook sink: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// renderEntry formats entry per format, either as JSON or as logfmt
+// key=value pairs for a human tailing the console.
+func renderEntry(format SinkFormat, entry LogEntry) ([]byte, error) {
+	if format == SinkFormatLogfmt {
+		return renderLogfmt(entry), nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+func renderLogfmt(entry LogEntry) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "time=%s level=%s msg=%q", entry.Timestamp.Format(time.RFC3339), entry.Level, entry.Message)
+
+	if entry.Component != "" {
+		fmt.Fprintf(&b, " component=%s", entry.Component)
+	}
+	if entry.CorrelationID != "" {
+		fmt.Fprintf(&b, " correlation_id=%s", entry.CorrelationID)
+	}
+	if entry.TaskID != "" {
+		fmt.Fprintf(&b, " task_id=%s", entry.TaskID)
+	}
+	if entry.ProxyHost != "" {
+		fmt.Fprintf(&b, " proxy=%s:%d", entry.ProxyHost, entry.ProxyPort)
+	}
+	if entry.StatusCode != 0 {
+		fmt.Fprintf(&b, " status_code=%d", entry.StatusCode)
+	}
+	if entry.Error != "" {
+		fmt.Fprintf(&b, " error=%q", entry.Error)
+	}
+
+	keys := make([]string, 0, len(entry.Fields))
+	for k := range entry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, entry.Fields[k])
+	}
+
+	b.WriteByte('\n')
+	return []byte(b.String())
+}
+
+// levelFromString reverses LogLevel.String, used to apply a sink's Level
+// floor against a LogEntry built elsewhere.
+func levelFromString(s string) LogLevel {
+	switch s {
+	case "DEBUG":
+		return DEBUG
+	case "WARN":
+		return WARN
+	case "ERROR":
+		return ERROR
+	case "FATAL":
+		return FATAL
+	default:
+		return INFO
+	}
+}
+
+const sinkFanoutQueueSize = 1000
+
+// sinkFanout is the LogExporter NewStructuredLogger registers when
+// LoggerConfig.Sinks is non-empty: a single background goroutine drains a
+// bounded queue and delivers each entry to every configured sink, logging
+// (rather than propagating) a sink's delivery error so one failing
+// destination doesn't stop delivery to the others. Sinks are written one
+// after another on that goroutine, so a slow sink (a webhook working through
+// its retries, say) delays delivery to the rest.
+type sinkFanout struct {
+	sinks []*filteredSink
+
+	queue     chan LogEntry
+	dropped   func(entry LogEntry)
+	flushReq  chan chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// newSinkFanout builds the sinks described by configs and starts the
+// background delivery goroutine.
+func newSinkFanout(configs []SinkConfig) (*sinkFanout, error) {
+	sinks := make([]*filteredSink, 0, len(configs))
+	for _, cfg := range configs {
+		sink, err := buildSink(cfg)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	f := &sinkFanout{
+		sinks:    sinks,
+		queue:    make(chan LogEntry, sinkFanoutQueueSize),
+		flushReq: make(chan chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	f.wg.Add(1)
+	go f.run()
+
+	return f, nil
+}
+
+// Export enqueues entries for background delivery, dropping (not blocking
+// the logging call site) if the queue is saturated.
+func (f *sinkFanout) Export(ctx context.Context, entries []LogEntry) error {
+	for _, entry := range entries {
+		select {
+		case f.queue <- entry:
+		default:
+		}
+	}
+	return nil
+}
+
+// Flush blocks until every entry enqueued before the call has been
+// delivered (or has failed) on every sink.
+func (f *sinkFanout) Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case f.flushReq <- ack:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-f.done:
+		return nil
+	}
+
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the background goroutine and closes every sink that owns a
+// file handle or connection.
+func (f *sinkFanout) Close() error {
+	f.closeOnce.Do(func() {
+		close(f.done)
+	})
+	f.wg.Wait()
+
+	var firstErr error
+	for _, sink := range f.sinks {
+		if sink.close == nil {
+			continue
+		}
+		if err := sink.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (f *sinkFanout) run() {
+	defer f.wg.Done()
+
+	deliverAll := func(entry LogEntry) {
+		for _, sink := range f.sinks {
+			if err := sink.deliver(entry); err != nil {
+				fmt.Fprintf(os.Stderr, "[WARN] log sink delivery failed: %v\n", err)
+			}
+		}
+	}
+
+	for {
+		select {
+		case entry := <-f.queue:
+			deliverAll(entry)
+		case ack := <-f.flushReq:
+			f.drainQueue(deliverAll)
+			close(ack)
+		case <-f.done:
+			f.drainQueue(deliverAll)
+			return
+		}
+	}
+}
+
+func (f *sinkFanout) drainQueue(deliverAll func(LogEntry)) {
+	for {
+		select {
+		case entry := <-f.queue:
+			deliverAll(entry)
+		default:
+			return
+		}
+	}
+}
+
+var _ LogExporter = (*sinkFanout)(nil)
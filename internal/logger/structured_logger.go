@@ -115,31 +115,31 @@ func (h *CheckerHandler) Enabled(ctx context.Context, level slog.Level) bool {
 // Handle handles the Record
 func (h *CheckerHandler) Handle(ctx context.Context, r slog.Record) error {
 	buf := make([]byte, 0, 1024)
-	
+
 	// Format timestamp
 	if !r.Time.IsZero() {
 		buf = append(buf, '[')
 		buf = r.Time.AppendFormat(buf, "2006-01-02 15:04:05")
 		buf = append(buf, "] "...)
 	}
-	
+
 	// Format level
 	buf = append(buf, r.Level.String()...)
 	buf = append(buf, ' ')
-	
+
 	// Format component
 	if h.component != "" {
 		buf = append(buf, '[')
 		buf = append(buf, h.component...)
 		buf = append(buf, ']')
 	}
-	
+
 	// Extract and format contextual information from pre-formatted attrs
 	var correlationID, taskID, proxyHost string
 	var proxyPort, latency, statusCode, retryAttempt int
 	var timeout time.Duration
 	var errorStr string
-	
+
 	// Process pre-formatted attributes from WithAttrs
 	for _, a := range h.attrs {
 		switch a.Key {
@@ -163,7 +163,7 @@ func (h *CheckerHandler) Handle(ctx context.Context, r slog.Record) error {
 			errorStr = a.Value.String()
 		}
 	}
-	
+
 	// Process record attributes
 	r.Attrs(func(a slog.Attr) bool {
 		switch a.Key {
@@ -188,7 +188,7 @@ func (h *CheckerHandler) Handle(ctx context.Context, r slog.Record) error {
 		}
 		return true
 	})
-	
+
 	// Append contextual information
 	if correlationID != "" {
 		buf = append(buf, " [CID:"...)
@@ -227,17 +227,17 @@ func (h *CheckerHandler) Handle(ctx context.Context, r slog.Record) error {
 		buf = append(buf, timeout.String()...)
 		buf = append(buf, ']')
 	}
-	
+
 	// Format message
 	buf = append(buf, ' ')
 	buf = append(buf, r.Message...)
-	
+
 	// Format error if present
 	if errorStr != "" {
 		buf = append(buf, " - Error: "...)
 		buf = append(buf, errorStr...)
 	}
-	
+
 	// Add other fields
 	hasOtherFields := false
 	r.Attrs(func(a slog.Attr) bool {
@@ -247,22 +247,22 @@ func (h *CheckerHandler) Handle(ctx context.Context, r slog.Record) error {
 			"latency_ms", "status_code", "retry_attempt", "timeout_ms", "error":
 			return true
 		}
-		
+
 		if !hasOtherFields {
 			buf = append(buf, "\n  Fields: "...)
 			hasOtherFields = true
 		} else {
 			buf = append(buf, ", "...)
 		}
-		
+
 		buf = append(buf, a.Key...)
 		buf = append(buf, '=')
 		buf = append(buf, fmt.Sprintf("%v", a.Value.Any())...)
 		return true
 	})
-	
+
 	buf = append(buf, '\n')
-	
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	_, err := h.output.Write(buf)
@@ -274,7 +274,7 @@ func (h *CheckerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	newAttrs := make([]slog.Attr, len(h.attrs)+len(attrs))
 	copy(newAttrs, h.attrs)
 	copy(newAttrs[len(h.attrs):], attrs)
-	
+
 	return &CheckerHandler{
 		opts:      h.opts,
 		output:    h.output,
@@ -290,11 +290,11 @@ func (h *CheckerHandler) WithGroup(name string) slog.Handler {
 	if name == "" {
 		return h
 	}
-	
+
 	newGroups := make([]string, len(h.groups)+1)
 	copy(newGroups, h.groups)
 	newGroups[len(h.groups)] = name
-	
+
 	return &CheckerHandler{
 		opts:      h.opts,
 		output:    h.output,
@@ -309,13 +309,26 @@ func (h *CheckerHandler) WithGroup(name string) slog.Handler {
 type StructuredLogger struct {
 	logger     *slog.Logger
 	level      LogLevel
-	fileOutput *os.File
+	fileOutput io.WriteCloser
 	jsonFormat bool
 	sessionID  string
 	component  string
 	bufferSize int
 	buffer     []LogEntry
 	bufferMu   sync.Mutex
+
+	exportersMu sync.Mutex
+	exporters   []LogExporter
+
+	// sinkFanout is set when LoggerConfig.Sinks is non-empty, so Close can
+	// stop its background goroutine and close any file/connection-backed
+	// sinks it owns.
+	sinkFanout *sinkFanout
+
+	// remoteControl mirrors LoggerConfig.RemoteControl, letting callers
+	// (the checker's debug API) check whether this logger's buffer was
+	// opted into remote access before serving it over HTTP.
+	remoteControl bool
 }
 
 // LoggerConfig for StructuredLogger
@@ -325,35 +338,83 @@ type LoggerConfig struct {
 	OutputFile string   `json:"output_file"`
 	BufferSize int      `json:"buffer_size"`
 	Component  string   `json:"component"`
+
+	// Backend selects the Logger implementation NewLogger constructs.
+	// Defaults to BackendSlog (this file's StructuredLogger) when unset.
+	Backend Backend `json:"backend"`
+
+	// Rotation enables size/age/daily rotation of OutputFile. Nil disables
+	// rotation and opens OutputFile as a plain append-only file, as before.
+	// Ignored when AsyncRotation is also set.
+	Rotation *RotationConfig `json:"rotation,omitempty"`
+
+	// AsyncRotation enables batched, background-goroutine-driven rotation
+	// of OutputFile instead of RotatingWriter's synchronous per-Write
+	// rotation, for presets that log heavily enough that the hot path
+	// shouldn't touch the file at all. Takes precedence over Rotation.
+	AsyncRotation *AsyncRotationConfig `json:"async_rotation,omitempty"`
+
+	// Sampling throttles high-frequency events per level and per
+	// (component, event_type, status_code) key. Nil disables sampling and
+	// logs everything, as before.
+	Sampling *SamplingConfig `json:"sampling,omitempty"`
+
+	// Sinks fans every log entry out to additional destinations beyond the
+	// primary OutputFile/stdout handler above - e.g. a human-readable
+	// console sink alongside a JSON file and a webhook that only fires on
+	// ERROR. Empty disables the fan-out entirely.
+	Sinks []SinkConfig `json:"sinks,omitempty"`
+
+	// RemoteControl opts this logger's buffer into the checker's debug REST
+	// API (GET /log), letting an operator pull a paste-ready trace at
+	// runtime without restarting. Defaults to false: a logger's buffer can
+	// hold sensitive request/response bodies, so it shouldn't be reachable
+	// over HTTP unless a config explicitly asks for it.
+	RemoteControl bool `json:"remote_control"`
 }
 
 // NewStructuredLogger creates a new structured logger using slog
 func NewStructuredLogger(config LoggerConfig) (*StructuredLogger, error) {
 	sessionID := generateSessionID()
-	
+
 	var handler slog.Handler
-	var fileOutput *os.File
-	
+	var fileOutput io.WriteCloser
+
 	// Set up file output if specified
 	if config.OutputFile != "" {
 		dir := filepath.Dir(config.OutputFile)
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return nil, fmt.Errorf("failed to create log directory: %v", err)
 		}
-		
-		file, err := os.OpenFile(config.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open log file: %v", err)
+
+		switch {
+		case config.AsyncRotation != nil:
+			rotator, err := NewAsyncRotatingWriter(config.OutputFile, *config.AsyncRotation)
+			if err != nil {
+				return nil, err
+			}
+			fileOutput = rotator
+		case config.Rotation != nil:
+			rotator, err := NewRotatingWriter(config.OutputFile, *config.Rotation)
+			if err != nil {
+				return nil, err
+			}
+			fileOutput = rotator
+		default:
+			file, err := os.OpenFile(config.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open log file: %v", err)
+			}
+			fileOutput = file
 		}
-		fileOutput = file
 	}
-	
+
 	// Create handler based on format
 	opts := &slog.HandlerOptions{
-		Level: config.Level.toSlogLevel(),
+		Level:     config.Level.toSlogLevel(),
 		AddSource: false,
 	}
-	
+
 	if config.JSONFormat {
 		// Use JSON handler for JSON format
 		if fileOutput != nil {
@@ -369,19 +430,68 @@ func NewStructuredLogger(config LoggerConfig) (*StructuredLogger, error) {
 			handler = NewCheckerHandler(os.Stdout, opts, config.Component, sessionID)
 		}
 	}
-	
+
+	if config.Sampling != nil {
+		handler = NewSamplingHandler(handler, *config.Sampling, config.Component)
+	}
+
 	logger := slog.New(handler)
-	
-	return &StructuredLogger{
-		logger:     logger,
-		level:      config.Level,
-		fileOutput: fileOutput,
-		jsonFormat: config.JSONFormat,
-		sessionID:  sessionID,
-		component:  config.Component,
-		bufferSize: config.BufferSize,
-		buffer:     make([]LogEntry, 0, config.BufferSize),
-	}, nil
+
+	sl := &StructuredLogger{
+		logger:        logger,
+		level:         config.Level,
+		fileOutput:    fileOutput,
+		jsonFormat:    config.JSONFormat,
+		sessionID:     sessionID,
+		component:     config.Component,
+		bufferSize:    config.BufferSize,
+		buffer:        make([]LogEntry, 0, config.BufferSize),
+		remoteControl: config.RemoteControl,
+	}
+
+	if len(config.Sinks) > 0 {
+		fanout, err := newSinkFanout(config.Sinks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build log sinks: %v", err)
+		}
+		sl.sinkFanout = fanout
+		sl.RegisterExporter(fanout)
+	}
+
+	return sl, nil
+}
+
+// Rotate manually triggers rotation of OutputFile (e.g. on SIGHUP) when the
+// logger was configured with LoggerConfig.Rotation or AsyncRotation. It's a
+// no-op otherwise.
+func (sl *StructuredLogger) Rotate() error {
+	switch rotator := sl.fileOutput.(type) {
+	case *RotatingWriter:
+		return rotator.Rotate()
+	case *AsyncRotatingWriter:
+		return rotator.Rotate()
+	default:
+		return nil
+	}
+}
+
+// samplingHandler returns the logger's outermost slog.Handler as a
+// SamplingHandler, if it is one (the logger was configured with
+// LoggerConfig.Sampling); handlers further down the chain are not inspected.
+func (sl *StructuredLogger) samplingHandler() (*SamplingHandler, bool) {
+	h, ok := sl.logger.Handler().(*SamplingHandler)
+	return h, ok
+}
+
+// SamplingSummary returns how many records have been suppressed by sampling
+// so far, overall and per (component, event_type, status_code) key, for a
+// periodic "N logs suppressed" line. Returns ok=false if sampling isn't configured.
+func (sl *StructuredLogger) SamplingSummary() (total uint64, byKey map[string]uint64, ok bool) {
+	h, ok := sl.samplingHandler()
+	if !ok {
+		return 0, nil, false
+	}
+	return h.DroppedCount(), h.DroppedByKey(), true
 }
 
 // generateSessionID creates a unique session identifier
@@ -390,44 +500,60 @@ func generateSessionID() string {
 }
 
 // Debug logs a debug message
-func (sl *StructuredLogger) Debug(message string, fields ...map[string]interface{}) {
-	sl.logWithFields(slog.LevelDebug, message, "", fields...)
+func (sl *StructuredLogger) Debug(ctx context.Context, message string, fields ...map[string]interface{}) {
+	sl.logWithFields(ctx, slog.LevelDebug, message, "", fields...)
 }
 
 // Info logs an info message
-func (sl *StructuredLogger) Info(message string, fields ...map[string]interface{}) {
-	sl.logWithFields(slog.LevelInfo, message, "", fields...)
+func (sl *StructuredLogger) Info(ctx context.Context, message string, fields ...map[string]interface{}) {
+	sl.logWithFields(ctx, slog.LevelInfo, message, "", fields...)
 }
 
 // Warn logs a warning message
-func (sl *StructuredLogger) Warn(message string, fields ...map[string]interface{}) {
-	sl.logWithFields(slog.LevelWarn, message, "", fields...)
+func (sl *StructuredLogger) Warn(ctx context.Context, message string, fields ...map[string]interface{}) {
+	sl.logWithFields(ctx, slog.LevelWarn, message, "", fields...)
 }
 
 // Error logs an error message
-func (sl *StructuredLogger) Error(message string, err error, fields ...map[string]interface{}) {
+func (sl *StructuredLogger) Error(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
 	errorStr := ""
 	if err != nil {
 		errorStr = err.Error()
 	}
-	sl.logWithFields(slog.LevelError, message, errorStr, fields...)
+	sl.logWithFields(ctx, slog.LevelError, message, errorStr, fields...)
 }
 
 // Fatal logs a fatal message and exits
-func (sl *StructuredLogger) Fatal(message string, err error, fields ...map[string]interface{}) {
+func (sl *StructuredLogger) Fatal(ctx context.Context, message string, err error, fields ...map[string]interface{}) {
 	errorStr := ""
 	if err != nil {
 		errorStr = err.Error()
 	}
-	sl.logWithFields(slog.LevelError+1, message, errorStr, fields...)
+	sl.logWithFields(ctx, slog.LevelError+1, message, errorStr, fields...)
 	os.Exit(1)
 }
 
+// V reports whether level would actually be emitted, so callers can skip
+// building expensive field maps for a level that's about to be discarded.
+func (sl *StructuredLogger) V(level LogLevel) bool {
+	return level >= sl.level
+}
+
+// With returns a Logger that merges base into every field map passed to
+// future calls, without mutating sl itself.
+func (sl *StructuredLogger) With(base map[string]interface{}) Logger {
+	return &boundLogger{base: sl, fields: base}
+}
+
 // logWithFields is the internal logging method
-func (sl *StructuredLogger) logWithFields(level slog.Level, message string, errorStr string, fields ...map[string]interface{}) {
+func (sl *StructuredLogger) logWithFields(ctx context.Context, level slog.Level, message string, errorStr string, fields ...map[string]interface{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	// Build attributes from fields
 	attrs := make([]slog.Attr, 0, 10)
-	
+
 	if len(fields) > 0 {
 		for _, fieldMap := range fields {
 			for k, v := range fieldMap {
@@ -435,25 +561,87 @@ func (sl *StructuredLogger) logWithFields(level slog.Level, message string, erro
 			}
 		}
 	}
-	
+
 	if errorStr != "" {
 		attrs = append(attrs, slog.String("error", errorStr))
 	}
-	
+
 	// Log with context
-	sl.logger.LogAttrs(context.Background(), level, message, attrs...)
-	
+	sl.logger.LogAttrs(ctx, level, message, attrs...)
+
 	// Add to buffer if buffering is enabled
 	if sl.bufferSize > 0 {
 		sl.addToBuffer(level, message, errorStr, fields...)
 	}
+
+	sl.exportEntry(sl.buildEntry(level, message, errorStr, fields...))
+}
+
+// buildEntry assembles a LogEntry from the same inputs logWithFields and
+// addToBuffer already work with, so buffering and exporting stay consistent.
+func (sl *StructuredLogger) buildEntry(level slog.Level, message string, errorStr string, fields ...map[string]interface{}) LogEntry {
+	entry := LogEntry{
+		Timestamp: time.Now(),
+		Level:     level.String(),
+		Message:   message,
+		Component: sl.component,
+		Session:   sl.sessionID,
+		Error:     errorStr,
+	}
+
+	if len(fields) > 0 {
+		entry.Fields = make(map[string]interface{})
+		for _, fieldMap := range fields {
+			for k, v := range fieldMap {
+				entry.Fields[k] = v
+			}
+		}
+	}
+
+	return entry
+}
+
+// RegisterExporter adds a LogExporter so every future log line is fanned out
+// to it in addition to the internal buffer and slog handler. Multiple sinks
+// (file, stdout, OTLP, custom) can coexist.
+func (sl *StructuredLogger) RegisterExporter(exp LogExporter) {
+	sl.exportersMu.Lock()
+	defer sl.exportersMu.Unlock()
+	sl.exporters = append(sl.exporters, exp)
+}
+
+// exportEntry fans a single entry out to every registered exporter.
+func (sl *StructuredLogger) exportEntry(entry LogEntry) {
+	sl.exportersMu.Lock()
+	exporters := append([]LogExporter{}, sl.exporters...)
+	sl.exportersMu.Unlock()
+
+	for _, exp := range exporters {
+		exp.Export(context.Background(), []LogEntry{entry})
+	}
+}
+
+// Flush drains pending batches on every registered exporter, used from Close
+// so buffered log records aren't lost on shutdown.
+func (sl *StructuredLogger) Flush(ctx context.Context) error {
+	sl.exportersMu.Lock()
+	exporters := append([]LogExporter{}, sl.exporters...)
+	sl.exportersMu.Unlock()
+
+	var firstErr error
+	for _, exp := range exporters {
+		if err := exp.Flush(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 // addToBuffer adds an entry to the internal buffer
 func (sl *StructuredLogger) addToBuffer(level slog.Level, message string, errorStr string, fields ...map[string]interface{}) {
 	sl.bufferMu.Lock()
 	defer sl.bufferMu.Unlock()
-	
+
 	entry := LogEntry{
 		Timestamp: time.Now(),
 		Level:     level.String(),
@@ -462,7 +650,7 @@ func (sl *StructuredLogger) addToBuffer(level slog.Level, message string, errorS
 		Session:   sl.sessionID,
 		Error:     errorStr,
 	}
-	
+
 	if len(fields) > 0 {
 		entry.Fields = make(map[string]interface{})
 		for _, fieldMap := range fields {
@@ -471,9 +659,9 @@ func (sl *StructuredLogger) addToBuffer(level slog.Level, message string, errorS
 			}
 		}
 	}
-	
+
 	sl.buffer = append(sl.buffer, entry)
-	
+
 	// Keep buffer within size limit
 	if len(sl.buffer) > sl.bufferSize {
 		sl.buffer = sl.buffer[len(sl.buffer)-sl.bufferSize:]
@@ -484,21 +672,28 @@ func (sl *StructuredLogger) addToBuffer(level slog.Level, message string, errorS
 func (sl *StructuredLogger) GetRecentLogs(limit int) []LogEntry {
 	sl.bufferMu.Lock()
 	defer sl.bufferMu.Unlock()
-	
+
 	if limit <= 0 || limit > len(sl.buffer) {
 		limit = len(sl.buffer)
 	}
-	
+
 	start := len(sl.buffer) - limit
 	if start < 0 {
 		start = 0
 	}
-	
+
 	result := make([]LogEntry, limit)
 	copy(result, sl.buffer[start:])
 	return result
 }
 
+// RemoteControlEnabled reports whether this logger was configured with
+// LoggerConfig.RemoteControl, i.e. whether its buffer is safe to expose
+// through the checker's debug REST API.
+func (sl *StructuredLogger) RemoteControlEnabled() bool {
+	return sl.remoteControl
+}
+
 // SetLevel changes the logging level
 func (sl *StructuredLogger) SetLevel(level LogLevel) {
 	sl.level = level
@@ -511,6 +706,18 @@ func (sl *StructuredLogger) SetComponent(component string) {
 
 // Close closes the logger and any file handles
 func (sl *StructuredLogger) Close() error {
+	flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := sl.Flush(flushCtx); err != nil {
+		fmt.Printf("[WARN] failed to flush log exporters on close: %v\n", err)
+	}
+
+	if sl.sinkFanout != nil {
+		if err := sl.sinkFanout.Close(); err != nil {
+			fmt.Printf("[WARN] failed to close log sinks: %v\n", err)
+		}
+	}
+
 	if sl.fileOutput != nil {
 		return sl.fileOutput.Close()
 	}
@@ -522,18 +729,18 @@ func (sl *StructuredLogger) LogCheckerEvent(eventType string, result types.Check
 	if fields == nil {
 		fields = make(map[string]interface{})
 	}
-	
+
 	fields["event_type"] = eventType
 	fields["combo"] = result.Combo.Username
 	fields["config"] = result.Config
 	fields["status"] = string(result.Status)
 	fields["latency"] = result.Latency
-	
+
 	if result.Proxy != nil {
 		fields["proxy"] = fmt.Sprintf("%s:%d", result.Proxy.Host, result.Proxy.Port)
 	}
-	
-	sl.Info(fmt.Sprintf("Checker event: %s", eventType), fields)
+
+	sl.Info(context.Background(), fmt.Sprintf("Checker event: %s", eventType), fields)
 }
 
 // LogProxyEvent logs proxy-related events
@@ -541,57 +748,19 @@ func (sl *StructuredLogger) LogProxyEvent(eventType string, proxy types.Proxy, f
 	if fields == nil {
 		fields = make(map[string]interface{})
 	}
-	
+
 	fields["event_type"] = eventType
 	fields["proxy_host"] = proxy.Host
 	fields["proxy_port"] = proxy.Port
 	fields["proxy_type"] = string(proxy.Type)
 	fields["proxy_score"] = proxy.Score
 	fields["proxy_quality"] = string(proxy.Quality)
-	
+
 	if proxy.Location != nil {
 		fields["proxy_country"] = proxy.Location.Country
 	}
-	
-	sl.Info(fmt.Sprintf("Proxy event: %s", eventType), fields)
-}
 
-// ExportLogs exports recent logs to a file
-func (sl *StructuredLogger) ExportLogs(filename string, limit int) error {
-	logs := sl.GetRecentLogs(limit)
-	
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	
-	// Write as JSON
-	fmt.Fprintf(file, "{\n")
-	fmt.Fprintf(file, "  \"exported_at\": \"%s\",\n", time.Now().Format(time.RFC3339))
-	fmt.Fprintf(file, "  \"session_id\": \"%s\",\n", sl.sessionID)
-	fmt.Fprintf(file, "  \"total_logs\": %d,\n", len(logs))
-	fmt.Fprintf(file, "  \"logs\": [\n")
-	
-	for i, log := range logs {
-		fmt.Fprintf(file, "    {")
-		fmt.Fprintf(file, "\"timestamp\":\"%s\",", log.Timestamp.Format(time.RFC3339))
-		fmt.Fprintf(file, "\"level\":\"%s\",", log.Level)
-		fmt.Fprintf(file, "\"message\":\"%s\"", log.Message)
-		if log.Error != "" {
-			fmt.Fprintf(file, ",\"error\":\"%s\"", log.Error)
-		}
-		fmt.Fprintf(file, "}")
-		if i < len(logs)-1 {
-			fmt.Fprintf(file, ",")
-		}
-		fmt.Fprintf(file, "\n")
-	}
-	
-	fmt.Fprintf(file, "  ]\n")
-	fmt.Fprintf(file, "}\n")
-	
-	return nil
+	sl.Info(context.Background(), fmt.Sprintf("Proxy event: %s", eventType), fields)
 }
 
 // LogWithCorrelation logs with correlation ID for request tracing
@@ -600,166 +769,139 @@ func (sl *StructuredLogger) LogWithCorrelation(level LogLevel, message string, c
 		fields = make(map[string]interface{})
 	}
 	fields["correlation_id"] = correlationID
-	
+
+	ctx := context.Background()
 	switch level {
 	case DEBUG:
-		sl.Debug(message, fields)
+		sl.Debug(ctx, message, fields)
 	case INFO:
-		sl.Info(message, fields)
+		sl.Info(ctx, message, fields)
 	case WARN:
-		sl.Warn(message, fields)
+		sl.Warn(ctx, message, fields)
 	case ERROR:
-		sl.Error(message, nil, fields)
+		sl.Error(ctx, message, nil, fields)
 	case FATAL:
-		sl.Fatal(message, nil, fields)
+		sl.Fatal(ctx, message, nil, fields)
 	}
 }
 
 // LogNetworkRequest logs network request details with timeout tracking
 func (sl *StructuredLogger) LogNetworkRequest(method, url string, statusCode int, latency time.Duration, proxy *types.Proxy, correlationID string, err error) {
-	logger := sl.logger.With(
-		slog.String("correlation_id", correlationID),
-		slog.Int("status_code", statusCode),
-		slog.Int("latency_ms", int(latency.Milliseconds())),
-	)
-	
+	fields := map[string]interface{}{
+		"method":         method,
+		"url":            url,
+		"status_code":    statusCode,
+		"latency_ms":     latency.Milliseconds(),
+		"correlation_id": correlationID,
+	}
 	if proxy != nil {
-		logger = logger.With(
-			slog.String("proxy_host", proxy.Host),
-			slog.Int("proxy_port", proxy.Port),
-		)
+		fields["proxy_host"] = proxy.Host
+		fields["proxy_port"] = proxy.Port
 	}
-	
+
 	message := fmt.Sprintf("Network request: %s %s", method, url)
-	
+	ctx := context.Background()
 	if err != nil {
-		logger.Error(message, slog.String("error", err.Error()))
+		sl.Error(ctx, message, err, fields)
 	} else {
-		logger.Info(message)
-	}
-	
-	// Add to buffer
-	if sl.bufferSize > 0 {
-		fields := map[string]interface{}{
-			"method":         method,
-			"url":            url,
-			"status_code":    statusCode,
-			"latency_ms":     latency.Milliseconds(),
-			"correlation_id": correlationID,
-		}
-		if proxy != nil {
-			fields["proxy_host"] = proxy.Host
-			fields["proxy_port"] = proxy.Port
-		}
-		if err != nil {
-			sl.addToBuffer(slog.LevelError, message, err.Error(), fields)
-		} else {
-			sl.addToBuffer(slog.LevelInfo, message, "", fields)
-		}
+		sl.Info(ctx, message, fields)
 	}
 }
 
 // LogProxySelection logs proxy selection decisions
 func (sl *StructuredLogger) LogProxySelection(strategy string, proxy *types.Proxy, alternatives int, correlationID string) {
-	logger := sl.logger.With(
-		slog.String("correlation_id", correlationID),
-		slog.String("strategy", strategy),
-		slog.Int("alternatives", alternatives),
-	)
-	
+	fields := map[string]interface{}{
+		"correlation_id": correlationID,
+		"strategy":       strategy,
+		"alternatives":   alternatives,
+	}
 	if proxy != nil {
-		logger = logger.With(
-			slog.String("proxy_host", proxy.Host),
-			slog.Int("proxy_port", proxy.Port),
-			slog.Float64("proxy_score", proxy.Score),
-		)
+		fields["proxy_host"] = proxy.Host
+		fields["proxy_port"] = proxy.Port
+		fields["proxy_score"] = proxy.Score
 	}
-	
-	logger.Debug(fmt.Sprintf("Proxy selected using %s strategy", strategy))
+
+	sl.Debug(context.Background(), fmt.Sprintf("Proxy selected using %s strategy", strategy), fields)
 }
 
 // LogHealthCheck logs health check results
 func (sl *StructuredLogger) LogHealthCheck(proxy *types.Proxy, success bool, latency time.Duration, err error) {
-	logger := sl.logger.With(
-		slog.String("proxy_host", proxy.Host),
-		slog.Int("proxy_port", proxy.Port),
-		slog.Bool("success", success),
-		slog.Int("latency_ms", int(latency.Milliseconds())),
-	)
-	
+	fields := map[string]interface{}{
+		"proxy_host": proxy.Host,
+		"proxy_port": proxy.Port,
+		"success":    success,
+		"latency_ms": latency.Milliseconds(),
+	}
+
 	message := fmt.Sprintf("Health check for proxy %s:%d", proxy.Host, proxy.Port)
-	
+	ctx := context.Background()
+
 	if !success {
 		if err != nil {
-			logger.Warn(message, slog.String("error", err.Error()))
-		} else {
-			logger.Warn(message)
+			fields["error"] = err.Error()
 		}
-	} else {
-		logger.Info(message)
+		sl.Warn(ctx, message, fields)
+		return
 	}
+	sl.Info(ctx, message, fields)
 }
 
 // LogTimeout logs timeout events with details
 func (sl *StructuredLogger) LogTimeout(operation string, timeout time.Duration, correlationID string, proxy *types.Proxy) {
-	logger := sl.logger.With(
-		slog.String("correlation_id", correlationID),
-		slog.String("operation", operation),
-		slog.Int("timeout_ms", int(timeout.Milliseconds())),
-	)
-	
+	fields := map[string]interface{}{
+		"correlation_id": correlationID,
+		"operation":      operation,
+		"timeout_ms":     timeout.Milliseconds(),
+	}
 	if proxy != nil {
-		logger = logger.With(
-			slog.String("proxy_host", proxy.Host),
-			slog.Int("proxy_port", proxy.Port),
-		)
+		fields["proxy_host"] = proxy.Host
+		fields["proxy_port"] = proxy.Port
 	}
-	
-	logger.Warn(fmt.Sprintf("Operation timeout: %s (%.2fs)", operation, timeout.Seconds()))
+
+	sl.Warn(context.Background(), fmt.Sprintf("Operation timeout: %s (%.2fs)", operation, timeout.Seconds()), fields)
 }
 
 // LogRetryAttempt logs retry attempts with context
 func (sl *StructuredLogger) LogRetryAttempt(operation string, attempt int, maxAttempts int, correlationID string, lastError error) {
-	logger := sl.logger.With(
-		slog.String("correlation_id", correlationID),
-		slog.String("operation", operation),
-		slog.Int("retry_attempt", attempt),
-		slog.Int("max_attempts", maxAttempts),
-	)
-	
+	fields := map[string]interface{}{
+		"correlation_id": correlationID,
+		"operation":      operation,
+		"retry_attempt":  attempt,
+		"max_attempts":   maxAttempts,
+	}
+
 	message := fmt.Sprintf("Retry attempt %d/%d for %s", attempt, maxAttempts, operation)
-	
 	if lastError != nil {
-		logger.Info(message, slog.String("error", lastError.Error()))
-	} else {
-		logger.Info(message)
+		fields["error"] = lastError.Error()
 	}
+	sl.Info(context.Background(), message, fields)
 }
 
 // LogTaskStart logs the start of a task with correlation ID
 func (sl *StructuredLogger) LogTaskStart(taskID string, taskType string, correlationID string) {
-	sl.logger.With(
-		slog.String("task_id", taskID),
-		slog.String("task_type", taskType),
-		slog.String("correlation_id", correlationID),
-	).Info(fmt.Sprintf("Task started: %s", taskType))
+	sl.Info(context.Background(), fmt.Sprintf("Task started: %s", taskType), map[string]interface{}{
+		"task_id":        taskID,
+		"task_type":      taskType,
+		"correlation_id": correlationID,
+	})
 }
 
 // LogTaskComplete logs task completion with performance metrics
 func (sl *StructuredLogger) LogTaskComplete(taskID string, taskType string, correlationID string, duration time.Duration, success bool, err error) {
-	logger := sl.logger.With(
-		slog.String("task_id", taskID),
-		slog.String("task_type", taskType),
-		slog.String("correlation_id", correlationID),
-		slog.Int("latency_ms", int(duration.Milliseconds())),
-		slog.Bool("success", success),
-	)
-	
+	fields := map[string]interface{}{
+		"task_id":        taskID,
+		"task_type":      taskType,
+		"correlation_id": correlationID,
+		"latency_ms":     duration.Milliseconds(),
+		"success":        success,
+	}
+
 	message := fmt.Sprintf("Task completed: %s (%.2fs)", taskType, duration.Seconds())
-	
+	ctx := context.Background()
+
 	if !success && err != nil {
-		logger.Error(message, slog.String("error", err.Error()))
-	} else {
-		logger.Info(message)
+		sl.Error(ctx, message, err, fields)
+		return
 	}
+	sl.Info(ctx, message, fields)
 }
@@ -0,0 +1,238 @@
+// Package workers provides a small, reusable worker-pool abstraction used by
+// Checker's long-running goroutine groups (combo checking, result writing).
+// It factors out the start/stop/resize/panic-recovery bookkeeping that used
+// to be hand-rolled once per pool into a single implementation, so a new
+// persistent worker loop can be wired in without reproducing the same
+// workerMutex/workerCancels dance.
+//
+// A Pool doesn't own a work queue itself - it just runs config.Size copies
+// of a caller-supplied WorkFunc, each on its own cancellable context, and
+// tracks how many are alive plus how many have panicked. Pools that pull
+// from a channel (as every current caller does) report that channel's depth
+// through Config.QueueDepthFunc instead of Pool managing a queue directly;
+// that keeps Pool agnostic to whatever's feeding it.
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"universal-checker/internal/logger"
+)
+
+// panicFacility tags every panic-recovery log line emitted by a Pool, so it
+// can be grepped/filtered independent of the ShouldDebug-gated facilities in
+// internal/logger/facility.go - a recovered panic is always worth logging,
+// not just when a debug facility happens to be enabled.
+const panicFacility = "workers"
+
+// PanicPolicy controls what happens to a pool's worker count after one of
+// its goroutines panics and is recovered.
+type PanicPolicy string
+
+const (
+	// PanicPolicyDrop lets the pool shrink by one after a panic - the
+	// panicking goroutine simply exits and is not replaced.
+	PanicPolicyDrop PanicPolicy = "drop"
+	// PanicPolicyRequeue immediately spawns a replacement worker, so a
+	// transient panic (a nil field off a malformed proxy response, say)
+	// doesn't quietly erode the pool's configured size over a long run.
+	PanicPolicyRequeue PanicPolicy = "requeue"
+)
+
+// WorkFunc is a single worker's entire lifetime: it should loop, honoring
+// ctx.Done() (or whatever channel-close signal it already relies on), and
+// return once there's no more work coming. Checker.worker and
+// Checker.processResults are both WorkFuncs.
+type WorkFunc func(ctx context.Context)
+
+// Config seeds a Pool's initial size and behavior. Size can be changed at
+// runtime via Pool.Resize.
+type Config struct {
+	// Name identifies this pool in logs, Metrics, and Checker.Resize's
+	// pool-name lookup - e.g. "combo_check", "result_writer".
+	Name string
+	// Size is the number of worker goroutines to start with.
+	Size int
+	// PanicPolicy controls whether a panicking worker is replaced. Defaults
+	// to PanicPolicyDrop.
+	PanicPolicy PanicPolicy
+	// QueueDepthFunc, if set, reports the backlog this pool is draining -
+	// typically len() of whatever channel its WorkFunc reads from - for
+	// Metrics. Nil means Metrics always reports a depth of zero.
+	QueueDepthFunc func() int
+}
+
+// Metrics is a point-in-time snapshot of a Pool's health, surfaced through
+// types.CheckerStats.PoolStats for the GUI's per-pool breakdown.
+type Metrics struct {
+	Name            string        `json:"name"`
+	Size            int           `json:"size"`
+	QueueDepth      int           `json:"queue_depth"`
+	PanicsRecovered uint64        `json:"panics_recovered"`
+	Uptime          time.Duration `json:"uptime"`
+}
+
+// Pool is a resizable group of goroutines all running the same WorkFunc.
+type Pool struct {
+	config Config
+	work   WorkFunc
+	logger *logger.StructuredLogger
+
+	mu      sync.Mutex
+	cancels []context.CancelFunc
+	ctx     context.Context
+
+	wg     sync.WaitGroup
+	panics uint64
+
+	startTime time.Time
+}
+
+// NewPool builds (without starting) a Pool that will run work on
+// config.Size goroutines once Start is called.
+func NewPool(config Config, work WorkFunc, l *logger.StructuredLogger) *Pool {
+	if config.PanicPolicy == "" {
+		config.PanicPolicy = PanicPolicyDrop
+	}
+
+	return &Pool{
+		config: config,
+		work:   work,
+		logger: l,
+	}
+}
+
+// Start spawns config.Size workers bound to ctx - cancelling ctx (or calling
+// Stop) tears down every worker in this pool independent of any other pool.
+func (p *Pool) Start(ctx context.Context) {
+	p.mu.Lock()
+	p.ctx = ctx
+	p.startTime = time.Now()
+	size := p.config.Size
+	p.mu.Unlock()
+
+	for i := 0; i < size; i++ {
+		p.spawnWorker(ctx)
+	}
+}
+
+// spawnWorker starts a single worker with its own cancellable child of
+// parent, so Resize can shrink the pool by cancelling a subset without
+// tearing down the whole pool.
+func (p *Pool) spawnWorker(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+
+	p.mu.Lock()
+	p.cancels = append(p.cancels, cancel)
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go p.runWorker(ctx)
+}
+
+func (p *Pool) runWorker(ctx context.Context) {
+	defer p.wg.Done()
+	defer p.recoverPanic(ctx)
+
+	p.work(ctx)
+}
+
+// recoverPanic is deferred around a worker's WorkFunc call. It always logs a
+// recovered panic - unlike ShouldDebug-gated tracing, this isn't optional -
+// and, under PanicPolicyRequeue, spawns a replacement worker so the pool
+// doesn't silently shrink out from under whoever configured its size.
+func (p *Pool) recoverPanic(ctx context.Context) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	atomic.AddUint64(&p.panics, 1)
+	if p.logger != nil {
+		p.logger.Error(ctx, fmt.Sprintf("recovered panic in worker pool %s", p.config.Name), fmt.Errorf("%v", r), map[string]interface{}{
+			"facility": panicFacility,
+			"pool":     p.config.Name,
+		})
+	}
+
+	if p.config.PanicPolicy == PanicPolicyRequeue {
+		p.mu.Lock()
+		parent := p.ctx
+		p.mu.Unlock()
+		if parent != nil && parent.Err() == nil {
+			p.spawnWorker(parent)
+		}
+	}
+}
+
+// Resize grows the pool by delta new workers, or shrinks it by cancelling
+// -delta of the currently running ones. Shrinking never drops below zero
+// workers even if delta's magnitude exceeds the current pool size.
+func (p *Pool) Resize(delta int) {
+	if delta > 0 {
+		p.mu.Lock()
+		parent := p.ctx
+		p.mu.Unlock()
+		for i := 0; i < delta; i++ {
+			p.spawnWorker(parent)
+		}
+		return
+	}
+
+	p.mu.Lock()
+	n := -delta
+	if n > len(p.cancels) {
+		n = len(p.cancels)
+	}
+	toCancel := append([]context.CancelFunc{}, p.cancels[len(p.cancels)-n:]...)
+	p.cancels = p.cancels[:len(p.cancels)-n]
+	p.mu.Unlock()
+
+	for _, cancel := range toCancel {
+		cancel()
+	}
+}
+
+// Size returns the current number of live workers.
+func (p *Pool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.cancels)
+}
+
+// Stop cancels every worker in the pool and waits for them to exit.
+func (p *Pool) Stop() {
+	p.mu.Lock()
+	cancels := p.cancels
+	p.cancels = nil
+	p.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	p.wg.Wait()
+}
+
+// Metrics returns a point-in-time snapshot of this pool's health.
+func (p *Pool) Metrics() Metrics {
+	depth := 0
+	if p.config.QueueDepthFunc != nil {
+		depth = p.config.QueueDepthFunc()
+	}
+
+	p.mu.Lock()
+	started := p.startTime
+	p.mu.Unlock()
+
+	return Metrics{
+		Name:            p.config.Name,
+		Size:            p.Size(),
+		QueueDepth:      depth,
+		PanicsRecovered: atomic.LoadUint64(&p.panics),
+		Uptime:          time.Since(started),
+	}
+}
@@ -1,10 +1,12 @@
 package httpclient
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,51 +14,272 @@ import (
 	"universal-checker/pkg/types"
 )
 
+// defaultFingerprintProfile is used by NewAzureTLSClient for callers that
+// don't care which browser is impersonated.
+const defaultFingerprintProfile = "chrome120"
+
 // AzureTLSClient wraps the azuretls-client to provide a standard HTTP client interface
 type AzureTLSClient struct {
 	client  *azuretls.Session
 	proxy   *types.Proxy
 	timeout time.Duration
+	profile FingerprintProfile
+	pins    map[string][]string
+
+	// PreHooks run in order before the method-switch dispatch in Do. Each
+	// hook may mutate req (URL, headers, body) or short-circuit the request
+	// by returning an error, which Do then returns to the caller unchanged.
+	PreHooks []func(*http.Request) error
+
+	// CallBacks run in order after the azuretls response has been converted
+	// to an *http.Response. Each callback may modify the response (status,
+	// headers, body) in place, e.g. to follow a JS-challenge redirect,
+	// inject a solved captcha cookie, or record timing for adaptive proxy
+	// ranking. A non-nil error aborts the chain and is returned by Do.
+	CallBacks []func(*http.Request, *http.Response) error
+
+	// MaxResponseBytes bounds how much of a response body Do keeps. Zero
+	// (the default) keeps the full body azuretls buffered.
+	MaxResponseBytes int64
+
+	jar        *CookieJar
+	sessionKey string
+}
+
+// protoFromNegotiated maps the HTTP version azuretls reports it negotiated
+// over ALPN into the Proto/ProtoMajor/ProtoMinor triple net/http expects,
+// instead of hardcoding HTTP/1.1 regardless of what was actually used.
+func protoFromNegotiated(proto string) (string, int, int) {
+	switch proto {
+	case "HTTP/2.0", "HTTP/2", "h2":
+		return "HTTP/2.0", 2, 0
+	case "HTTP/1.0":
+		return "HTTP/1.0", 1, 0
+	case "HTTP/1.1", "":
+		return "HTTP/1.1", 1, 1
+	default:
+		return proto, 1, 1
+	}
+}
+
+// ClientOptions configures a new AzureTLSClient. The zero value is valid:
+// Profile defaults to the built-in Chrome 120 fingerprint, Timeout defaults
+// to 30s, and Proxy/Pins are left empty.
+type ClientOptions struct {
+	Profile string
+	Proxy   *types.Proxy
+	Timeout time.Duration
+
+	// Pins maps a host to its pinned SPKI SHA-256 hashes (base64-encoded),
+	// protecting high-value endpoints (login pages, token issuers) from
+	// MITM by a rogue upstream proxy.
+	Pins map[string][]string
+
+	// MaxResponseBytes bounds how much of a response body Do keeps, for
+	// large downloads, SSE, or chunked anti-bot challenges. Zero means
+	// unbounded.
+	MaxResponseBytes int64
+
+	// Jar, if set, is consulted before dispatch and updated from Set-Cookie
+	// headers after every response, scoped by SessionKey.
+	Jar *CookieJar
+	// SessionKey namespaces Jar lookups so multiple logical sessions can
+	// share one AzureTLSClient without cookie crosstalk. Defaults to "default".
+	SessionKey string
 }
 
-// NewAzureTLSClient creates a new AzureTLS client with optional proxy support
+// PinningError indicates that an SSL pin check failed for a host, as
+// distinct from a generic TLS handshake or certificate error.
+type PinningError struct {
+	Host string
+	Err  error
+}
+
+func (e *PinningError) Error() string {
+	return fmt.Sprintf("ssl pinning failed for %s: %v", e.Host, e.Err)
+}
+
+func (e *PinningError) Unwrap() error {
+	return e.Err
+}
+
+// NewAzureTLSClient creates a new AzureTLS client with optional proxy support,
+// impersonating the default (Chrome 120) fingerprint profile.
 func NewAzureTLSClient(proxy *types.Proxy, timeout time.Duration) (*AzureTLSClient, error) {
-	session := azuretls.NewSession()
-	
-	// Apply Chrome browser fingerprint for better compatibility
-	err := session.ApplyJa3("771,4865-4866-4867-49195-49199-49196-49200-52393-52392-49171-49172-156-157-47-53,0-23-65281-10-11-35-16-5-13-18-51-45-43-27-17513,29-23-24,0", "chrome")
+	return NewAzureTLSClientWithProfile(defaultFingerprintProfile, proxy, timeout)
+}
+
+// NewAzureTLSClientWithProfile creates a new AzureTLS client impersonating the
+// named FingerprintProfile. Unlike the original NewAzureTLSClient, fingerprint
+// application failures are returned as errors rather than silently downgraded
+// to a bare User-Agent override, so fingerprint drift against azuretls surfaces
+// immediately instead of producing traffic that doesn't match what it claims to be.
+func NewAzureTLSClientWithProfile(profile string, proxy *types.Proxy, timeout time.Duration) (*AzureTLSClient, error) {
+	return NewAzureTLSClientWithOptions(ClientOptions{
+		Profile: profile,
+		Proxy:   proxy,
+		Timeout: timeout,
+	})
+}
+
+// NewAzureTLSClientWithOptions creates a new AzureTLS client from a full set
+// of ClientOptions, including per-host SSL pins.
+func NewAzureTLSClientWithOptions(opts ClientOptions) (*AzureTLSClient, error) {
+	profileName := opts.Profile
+	if profileName == "" {
+		profileName = defaultFingerprintProfile
+	}
+
+	fp, err := GetProfile(profileName)
 	if err != nil {
-		// If JA3 fails, continue without it
-		session.UserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+		return nil, err
 	}
-	
+
+	session := azuretls.NewSession()
+
 	// Configure proxy if provided
-	if proxy != nil {
-		proxyURL := fmt.Sprintf("%s://%s:%d", string(proxy.Type), proxy.Host, proxy.Port)
+	if opts.Proxy != nil {
+		proxyURL := fmt.Sprintf("%s://%s:%d", string(opts.Proxy.Type), opts.Proxy.Host, opts.Proxy.Port)
 		if err := session.SetProxy(proxyURL); err != nil {
 			return nil, fmt.Errorf("failed to set proxy: %v", err)
 		}
 	}
-	
+
 	// Set timeout
-	if timeout > 0 {
-		session.SetTimeout(timeout)
+	if opts.Timeout > 0 {
+		session.SetTimeout(opts.Timeout)
 	} else {
 		session.SetTimeout(30 * time.Second)
 	}
-	
+
 	// Disable certificate verification for compatibility
 	session.InsecureSkipVerify = true
-	
-	return &AzureTLSClient{
-		client:  session,
-		proxy:   proxy,
-		timeout: timeout,
-	}, nil
+
+	sessionKey := opts.SessionKey
+	if sessionKey == "" {
+		sessionKey = "default"
+	}
+
+	client := &AzureTLSClient{
+		client:           session,
+		proxy:            opts.Proxy,
+		timeout:          opts.Timeout,
+		pins:             make(map[string][]string),
+		MaxResponseBytes: opts.MaxResponseBytes,
+		jar:              opts.Jar,
+		sessionKey:       sessionKey,
+	}
+
+	if err := client.applyProfile(fp); err != nil {
+		return nil, err
+	}
+
+	for host, pins := range opts.Pins {
+		if err := client.AddPins(host, pins); err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// AddPins pins a host to a set of base64-encoded SPKI SHA-256 hashes,
+// guarding against MITM from a rogue upstream proxy on high-value targets
+// such as login endpoints and token issuers.
+func (c *AzureTLSClient) AddPins(host string, pins []string) error {
+	if err := c.client.AddPins(host, pins); err != nil {
+		return &PinningError{Host: host, Err: err}
+	}
+	c.pins[host] = pins
+	return nil
+}
+
+// ClearPins removes any pinning configured for a host.
+func (c *AzureTLSClient) ClearPins(host string) {
+	delete(c.pins, host)
+	c.client.AddPins(host, nil)
+}
+
+// reapplyPins re-pins every configured host against the current session,
+// needed because azuretls' SetProxy rebuilds the underlying connection pool.
+func (c *AzureTLSClient) reapplyPins() error {
+	for host, pins := range c.pins {
+		if err := c.client.AddPins(host, pins); err != nil {
+			return &PinningError{Host: host, Err: err}
+		}
+	}
+	return nil
+}
+
+// applyProfile pushes a FingerprintProfile's JA3, HTTP/2 settings, and
+// pseudo-header order onto the underlying azuretls session.
+func (c *AzureTLSClient) applyProfile(fp FingerprintProfile) error {
+	if err := c.client.ApplyJa3(fp.JA3, fp.Navigator); err != nil {
+		return fmt.Errorf("failed to apply JA3 for profile %s: %v", fp.Name, err)
+	}
+
+	if err := c.client.ApplyHTTP2(fp.HTTP2.http2FingerprintString()); err != nil {
+		return fmt.Errorf("failed to apply HTTP/2 fingerprint for profile %s: %v", fp.Name, err)
+	}
+
+	c.client.PHeaderOrder = fp.PseudoHeaderOrder
+	c.client.UserAgent = fp.UserAgent
+
+	c.profile = fp
+	return nil
+}
+
+// SetProfile switches the client to impersonate a different registered
+// fingerprint profile, re-applying JA3, HTTP/2, and pseudo-header settings.
+func (c *AzureTLSClient) SetProfile(profile string) error {
+	fp, err := GetProfile(profile)
+	if err != nil {
+		return err
+	}
+	return c.applyProfile(fp)
 }
 
-// Do executes an HTTP request using azuretls-client
+// GetProfile returns the name of the fingerprint profile currently applied.
+func (c *AzureTLSClient) GetProfile() string {
+	return c.profile.Name
+}
+
+// SetSessionKey changes which logical session's cookies subsequent requests
+// read from and write to, without tearing down the underlying TLS session.
+func (c *AzureTLSClient) SetSessionKey(key string) {
+	if key == "" {
+		key = "default"
+	}
+	c.sessionKey = key
+}
+
+// SaveJar writes the client's cookie jar (all sessions) as compact JSON.
+func (c *AzureTLSClient) SaveJar(w io.Writer) error {
+	if c.jar == nil {
+		return fmt.Errorf("client has no cookie jar configured")
+	}
+	return c.jar.Save(w)
+}
+
+// LoadJar replaces the client's cookie jar contents from a prior SaveJar
+// output, letting a long-lived credential check resume an authenticated
+// session across process restarts.
+func (c *AzureTLSClient) LoadJar(r io.Reader) error {
+	if c.jar == nil {
+		c.jar = NewCookieJar()
+	}
+	return c.jar.Load(r)
+}
+
+// Do executes an HTTP request using azuretls-client, running any configured
+// PreHooks before dispatch and CallBacks after the response is converted.
 func (c *AzureTLSClient) Do(req *http.Request) (*http.Response, error) {
+	for _, hook := range c.PreHooks {
+		if err := hook(req); err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle context timeout
 	if req.Context() != nil {
 		if deadline, ok := req.Context().Deadline(); ok {
@@ -66,7 +289,14 @@ func (c *AzureTLSClient) Do(req *http.Request) (*http.Response, error) {
 			}
 		}
 	}
-	
+
+	// Attach any cookies this session has previously stored for the target host.
+	if c.jar != nil {
+		for _, cookie := range c.jar.cookiesFor(c.sessionKey, req.URL) {
+			req.AddCookie(cookie)
+		}
+	}
+
 	// Set headers on session
 	c.client.OrderedHeaders = azuretls.OrderedHeaders{}
 	for name, values := range req.Header {
@@ -74,10 +304,10 @@ func (c *AzureTLSClient) Do(req *http.Request) (*http.Response, error) {
 			c.client.OrderedHeaders = append(c.client.OrderedHeaders, []string{name, value})
 		}
 	}
-	
+
 	var resp *azuretls.Response
 	var err error
-	
+
 	// Handle different HTTP methods
 	switch req.Method {
 	case "GET":
@@ -124,31 +354,59 @@ func (c *AzureTLSClient) Do(req *http.Request) (*http.Response, error) {
 	default:
 		return nil, fmt.Errorf("unsupported HTTP method: %s", req.Method)
 	}
-	
+
 	if err != nil {
 		return nil, err
 	}
-	
-	// Convert azuretls response to http.Response
+
+	proto, protoMajor, protoMinor := protoFromNegotiated(resp.Proto)
+
+	body := resp.Body
+	if c.MaxResponseBytes > 0 && int64(len(body)) > c.MaxResponseBytes {
+		body = body[:c.MaxResponseBytes]
+	}
+
+	// Convert azuretls response to http.Response. bytes.NewReader avoids the
+	// extra string copy strings.NewReader(string(resp.Body)) used to force.
 	httpResp := &http.Response{
 		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
 		StatusCode:    resp.StatusCode,
-		Proto:         "HTTP/1.1",
-		ProtoMajor:    1,
-		ProtoMinor:    1,
+		Proto:         proto,
+		ProtoMajor:    protoMajor,
+		ProtoMinor:    protoMinor,
 		Header:        make(http.Header),
-		Body:          io.NopCloser(strings.NewReader(string(resp.Body))),
-		ContentLength: int64(len(resp.Body)),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
 		Request:       req,
 	}
-	
-	// Convert headers from fhttp.Header to http.Header
+
+	// Convert headers from fhttp.Header to http.Header, preserving
+	// Content-Length/Transfer-Encoding verbatim from upstream rather than
+	// recomputing them from the (possibly truncated) buffered body.
 	for name, values := range resp.Header {
 		for _, value := range values {
 			httpResp.Header.Add(name, value)
 		}
 	}
-	
+	if cl := httpResp.Header.Get("Content-Length"); cl != "" {
+		if n, err := strconv.ParseInt(cl, 10, 64); err == nil {
+			httpResp.ContentLength = n
+		}
+	}
+	if te := httpResp.Header.Get("Transfer-Encoding"); te != "" {
+		httpResp.TransferEncoding = strings.Split(te, ",")
+	}
+
+	if c.jar != nil {
+		c.jar.setCookies(c.sessionKey, req.URL, httpResp.Cookies())
+	}
+
+	for _, callback := range c.CallBacks {
+		if err := callback(req, httpResp); err != nil {
+			return nil, err
+		}
+	}
+
 	return httpResp, nil
 }
 
@@ -181,11 +439,19 @@ func (c *AzureTLSClient) PostForm(url string, data url.Values) (*http.Response,
 // SetProxy updates the proxy configuration
 func (c *AzureTLSClient) SetProxy(proxy *types.Proxy) error {
 	c.proxy = proxy
+
+	var err error
 	if proxy != nil {
 		proxyURL := fmt.Sprintf("%s://%s:%d", string(proxy.Type), proxy.Host, proxy.Port)
-		return c.client.SetProxy(proxyURL)
+		err = c.client.SetProxy(proxyURL)
+	} else {
+		err = c.client.SetProxy("")
 	}
-	return c.client.SetProxy("")
+	if err != nil {
+		return err
+	}
+
+	return c.reapplyPins()
 }
 
 // SetTimeout updates the timeout configuration
@@ -0,0 +1,159 @@
+package httpclient
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"universal-checker/pkg/types"
+)
+
+// ChaosActionType identifies what a ChaosAction does to a request/response.
+type ChaosActionType string
+
+const (
+	// ChaosActionLatency delays the request by a duration drawn uniformly
+	// from [MinLatencyMS, MaxLatencyMS].
+	ChaosActionLatency ChaosActionType = "latency"
+	// ChaosActionCorruptBody flips random bytes in the response body at
+	// CorruptRate (0..1 fraction of bytes touched).
+	ChaosActionCorruptBody ChaosActionType = "corrupt_body"
+	// ChaosActionSyntheticStatus discards the real response and returns
+	// StatusCode with an empty body instead.
+	ChaosActionSyntheticStatus ChaosActionType = "synthetic_status"
+	// ChaosActionDropConnection fails the request as if the connection was
+	// reset mid-response, without contacting the upstream at all.
+	ChaosActionDropConnection ChaosActionType = "drop_connection"
+	// ChaosActionSwapProxy sends the request through BadProxy instead of
+	// the proxy the caller selected.
+	ChaosActionSwapProxy ChaosActionType = "swap_proxy"
+)
+
+// ChaosAction is one possible fault a ChaosHostPolicy may inject, chosen by
+// weighted random draw against the other actions configured for the host.
+type ChaosAction struct {
+	Type   ChaosActionType `yaml:"type" json:"type"`
+	Weight float64         `yaml:"weight" json:"weight"`
+
+	MinLatencyMS int `yaml:"min_latency_ms,omitempty" json:"min_latency_ms,omitempty"`
+	MaxLatencyMS int `yaml:"max_latency_ms,omitempty" json:"max_latency_ms,omitempty"`
+
+	CorruptRate float64 `yaml:"corrupt_rate,omitempty" json:"corrupt_rate,omitempty"`
+
+	StatusCode int `yaml:"status_code,omitempty" json:"status_code,omitempty"`
+
+	BadProxy *types.Proxy `yaml:"bad_proxy,omitempty" json:"bad_proxy,omitempty"`
+}
+
+// ChaosHostPolicy is the set of possible actions for requests to Host. Host
+// matches against req.URL.Hostname() exactly; use "*" for a catch-all.
+type ChaosHostPolicy struct {
+	Host    string        `yaml:"host" json:"host"`
+	Actions []ChaosAction `yaml:"actions" json:"actions"`
+}
+
+// ChaosPolicy is the full set of per-host rules loaded from YAML or pushed
+// via PUT /chaos/policy.
+type ChaosPolicy struct {
+	Hosts []ChaosHostPolicy `yaml:"hosts" json:"hosts"`
+}
+
+// hostPolicy returns the policy for host, falling back to a "*" catch-all
+// entry if one exists, or nil if neither matches.
+func (p ChaosPolicy) hostPolicy(host string) *ChaosHostPolicy {
+	var wildcard *ChaosHostPolicy
+	for i := range p.Hosts {
+		if p.Hosts[i].Host == host {
+			return &p.Hosts[i]
+		}
+		if p.Hosts[i].Host == "*" {
+			wildcard = &p.Hosts[i]
+		}
+	}
+	return wildcard
+}
+
+// ChaosPolicyEngine holds the live ChaosPolicy behind a mutex so it can be
+// swapped at runtime (PUT /chaos/policy) while requests are consulting it
+// concurrently.
+type ChaosPolicyEngine struct {
+	mu     sync.RWMutex
+	policy ChaosPolicy
+}
+
+// NewChaosPolicyEngine creates an engine with no rules configured; every
+// request passes through untouched until a policy is loaded or set.
+func NewChaosPolicyEngine() *ChaosPolicyEngine {
+	return &ChaosPolicyEngine{}
+}
+
+// LoadPolicyFile replaces the engine's policy with the YAML document at path.
+func (e *ChaosPolicyEngine) LoadPolicyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("chaos: failed to read policy file %s: %v", path, err)
+	}
+
+	var policy ChaosPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return fmt.Errorf("chaos: failed to parse policy file %s: %v", path, err)
+	}
+
+	e.SetPolicy(policy)
+	return nil
+}
+
+// SetPolicy atomically replaces the engine's policy, e.g. from the
+// /chaos/policy HTTP handler.
+func (e *ChaosPolicyEngine) SetPolicy(policy ChaosPolicy) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.policy = policy
+}
+
+// Policy returns a copy of the currently active policy.
+func (e *ChaosPolicyEngine) Policy() ChaosPolicy {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.policy
+}
+
+// Roll picks one action to inject for a request to host, or returns
+// ok=false if no rule matches host or the weighted draw lands on "do
+// nothing" (the implicit remainder of the weight space up to 1.0).
+func (e *ChaosPolicyEngine) Roll(host string) (ChaosAction, bool) {
+	e.mu.RLock()
+	rule := e.policy.hostPolicy(host)
+	e.mu.RUnlock()
+
+	if rule == nil || len(rule.Actions) == 0 {
+		return ChaosAction{}, false
+	}
+
+	var totalWeight float64
+	for _, action := range rule.Actions {
+		totalWeight += action.Weight
+	}
+	if totalWeight <= 0 {
+		return ChaosAction{}, false
+	}
+
+	// Weights are fractions of requests to fault-inject, not a probability
+	// distribution over just these actions - draw against 1.0 so
+	// under-weighted policies (e.g. 5% latency) mostly do nothing.
+	draw := rand.Float64()
+	if draw >= totalWeight {
+		return ChaosAction{}, false
+	}
+
+	for _, action := range rule.Actions {
+		if draw < action.Weight {
+			return action, true
+		}
+		draw -= action.Weight
+	}
+	return ChaosAction{}, false
+}
@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ChaosPolicyServer exposes PUT /chaos/policy so a running checker's fault
+// injection rules can be tuned without a restart, e.g. while iterating on a
+// types.Config's success/failure string matching.
+type ChaosPolicyServer struct {
+	addr       string
+	engine     *ChaosPolicyEngine
+	httpServer *http.Server
+}
+
+// NewChaosPolicyServer creates a server bound to addr that mutates engine.
+func NewChaosPolicyServer(addr string, engine *ChaosPolicyEngine) *ChaosPolicyServer {
+	return &ChaosPolicyServer{addr: addr, engine: engine}
+}
+
+// Start begins listening in a background goroutine.
+func (s *ChaosPolicyServer) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chaos/policy", s.handlePolicy)
+
+	s.httpServer = &http.Server{Addr: s.addr, Handler: mux}
+
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	go s.httpServer.Serve(ln)
+	return nil
+}
+
+// Stop gracefully shuts the listener down.
+func (s *ChaosPolicyServer) Stop() {
+	if s.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.httpServer.Shutdown(ctx)
+}
+
+// handlePolicy handles GET (current policy) and PUT (replace policy) requests.
+func (s *ChaosPolicyServer) handlePolicy(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.engine.Policy())
+
+	case http.MethodPut:
+		var policy ChaosPolicy
+		if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.engine.SetPolicy(policy)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "expected GET or PUT", http.StatusMethodNotAllowed)
+	}
+}
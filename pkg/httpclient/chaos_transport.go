@@ -0,0 +1,199 @@
+package httpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChaosEvent describes a single fault ChaosTransport injected, so callers
+// (checker.checkCombo) can correlate an analyzeResponse outcome back to the
+// specific fault that produced it.
+type ChaosEvent struct {
+	Host   string
+	Action ChaosAction
+	Detail string
+}
+
+// ChaosInjectedError is returned by ChaosTransport.Do when the policy engine
+// selected ChaosActionDropConnection, standing in for a real mid-response
+// connection reset.
+type ChaosInjectedError struct {
+	Host string
+}
+
+func (e *ChaosInjectedError) Error() string {
+	return fmt.Sprintf("chaos: injected connection drop for %s", e.Host)
+}
+
+// ChaosTransport wraps another HTTPClientInterface and, before forwarding
+// each request, consults a ChaosPolicyEngine to optionally inject latency,
+// corrupt the response body, return a synthetic status, drop the connection,
+// or swap the outbound proxy - so a types.Config's success/failure string
+// logic can be validated against adversarial network conditions.
+type ChaosTransport struct {
+	inner  HTTPClientInterface
+	policy *ChaosPolicyEngine
+
+	// OnInject, if set, is called synchronously for every action actually
+	// injected. checker.createHTTPClient wires this to log the event through
+	// c.logger with the request's correlation ID.
+	OnInject func(ChaosEvent)
+
+	// swapProxy rebuilds inner against a different proxy for
+	// ChaosActionSwapProxy. It's a func rather than a hard dependency on
+	// AzureTLSClient so ChaosTransport can wrap any HTTPClientInterface.
+	swapProxy func(action ChaosAction) (HTTPClientInterface, error)
+}
+
+// NewChaosTransport wraps inner with fault injection driven by policy.
+// swapProxy may be nil if ChaosActionSwapProxy is never configured.
+func NewChaosTransport(inner HTTPClientInterface, policy *ChaosPolicyEngine, swapProxy func(ChaosAction) (HTTPClientInterface, error)) *ChaosTransport {
+	return &ChaosTransport{inner: inner, policy: policy, swapProxy: swapProxy}
+}
+
+// Do forwards req to the wrapped client, first rolling the policy engine for
+// req's host and applying whatever action (if any) it selects.
+func (t *ChaosTransport) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	action, ok := t.policy.Roll(host)
+	if !ok {
+		return t.inner.Do(req)
+	}
+
+	switch action.Type {
+	case ChaosActionDropConnection:
+		t.emit(host, action, "connection dropped mid-request")
+		return nil, &ChaosInjectedError{Host: host}
+
+	case ChaosActionSyntheticStatus:
+		t.emit(host, action, fmt.Sprintf("synthetic status %d", action.StatusCode))
+		return syntheticResponse(req, action.StatusCode), nil
+
+	case ChaosActionSwapProxy:
+		if t.swapProxy == nil {
+			return t.inner.Do(req)
+		}
+		client, err := t.swapProxy(action)
+		if err != nil {
+			return t.inner.Do(req)
+		}
+		t.emit(host, action, "swapped to configured bad proxy")
+		return client.Do(req)
+
+	case ChaosActionLatency:
+		delay := latencyFor(action)
+		t.emit(host, action, fmt.Sprintf("injected %s latency", delay))
+		time.Sleep(delay)
+		return t.inner.Do(req)
+
+	case ChaosActionCorruptBody:
+		resp, err := t.inner.Do(req)
+		if err != nil || resp == nil {
+			return resp, err
+		}
+		t.emit(host, action, fmt.Sprintf("corrupted %.0f%% of response bytes", action.CorruptRate*100))
+		return corruptResponseBody(resp, action.CorruptRate), nil
+
+	default:
+		return t.inner.Do(req)
+	}
+}
+
+// Get performs a GET request through Do so chaos injection applies uniformly.
+func (t *ChaosTransport) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return t.Do(req)
+}
+
+// Post performs a POST request through Do so chaos injection applies uniformly.
+func (t *ChaosTransport) Post(rawURL, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return t.Do(req)
+}
+
+// PostForm performs a form-encoded POST through Do so chaos injection applies uniformly.
+func (t *ChaosTransport) PostForm(rawURL string, data url.Values) (*http.Response, error) {
+	return t.Post(rawURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+}
+
+func (t *ChaosTransport) emit(host string, action ChaosAction, detail string) {
+	if t.OnInject != nil {
+		t.OnInject(ChaosEvent{Host: host, Action: action, Detail: detail})
+	}
+}
+
+// latencyFor draws a duration uniformly from [MinLatencyMS, MaxLatencyMS],
+// tolerating a zero/inverted range by returning MinLatencyMS outright.
+func latencyFor(action ChaosAction) time.Duration {
+	min, max := action.MinLatencyMS, action.MaxLatencyMS
+	if max <= min {
+		return time.Duration(min) * time.Millisecond
+	}
+	jitter := rand.Intn(max - min + 1)
+	return time.Duration(min+jitter) * time.Millisecond
+}
+
+// syntheticResponse builds a minimal *http.Response carrying statusCode and
+// an empty body, standing in for the real upstream response.
+func syntheticResponse(req *http.Request, statusCode int) *http.Response {
+	if statusCode == 0 {
+		statusCode = http.StatusServiceUnavailable
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}
+}
+
+// corruptResponseBody flips a corruptRate fraction of the response body's
+// bytes to random values, then rewinds the body so downstream reads see the
+// corrupted content.
+func corruptResponseBody(resp *http.Response, corruptRate float64) *http.Response {
+	if resp.Body == nil || corruptRate <= 0 {
+		return resp
+	}
+
+	original, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		resp.Body = io.NopCloser(bytes.NewReader(nil))
+		return resp
+	}
+
+	corrupted := make([]byte, len(original))
+	copy(corrupted, original)
+	for i := range corrupted {
+		if rand.Float64() < corruptRate {
+			corrupted[i] = byte(rand.Intn(256))
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(corrupted))
+	resp.ContentLength = int64(len(corrupted))
+	return resp
+}
+
+// Ensure ChaosTransport implements HTTPClientInterface.
+var _ HTTPClientInterface = (*ChaosTransport)(nil)
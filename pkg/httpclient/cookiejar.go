@@ -0,0 +1,126 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// jarCookie is the JSON-serializable form of a single stored cookie.
+type jarCookie struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Path     string    `json:"path,omitempty"`
+	Domain   string    `json:"domain,omitempty"`
+	Expires  time.Time `json:"expires,omitempty"`
+	Secure   bool      `json:"secure,omitempty"`
+	HTTPOnly bool      `json:"http_only,omitempty"`
+}
+
+// CookieJar is a session-scoped cookie store for AzureTLSClient. Unlike
+// net/http/cookiejar.Jar, its contents can be exported and re-imported via
+// Save/Load, so long-lived credential checks can resume authenticated
+// sessions (CSRF token -> login -> 2FA redirect) across process restarts.
+// Cookies are additionally namespaced by SessionKey so several logical
+// sessions can share one AzureTLSClient without crosstalk.
+type CookieJar struct {
+	mu sync.Mutex
+	// sessions maps SessionKey -> host -> cookies.
+	sessions map[string]map[string][]jarCookie
+}
+
+// NewCookieJar creates an empty CookieJar.
+func NewCookieJar() *CookieJar {
+	return &CookieJar{sessions: make(map[string]map[string][]jarCookie)}
+}
+
+// cookiesFor returns the non-expired cookies stored for session/host.
+func (j *CookieJar) cookiesFor(session string, u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	var result []*http.Cookie
+	for _, c := range j.sessions[session][u.Hostname()] {
+		if !c.Expires.IsZero() && c.Expires.Before(now) {
+			continue
+		}
+		result = append(result, &http.Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			Secure:   c.Secure,
+			HttpOnly: c.HTTPOnly,
+		})
+	}
+	return result
+}
+
+// setCookies stores/updates cookies for session/host, replacing any existing
+// cookie with the same name and path.
+func (j *CookieJar) setCookies(session string, u *url.URL, cookies []*http.Cookie) {
+	if len(cookies) == 0 {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.sessions[session] == nil {
+		j.sessions[session] = make(map[string][]jarCookie)
+	}
+	host := u.Hostname()
+	existing := j.sessions[session][host]
+
+	for _, c := range cookies {
+		record := jarCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			Expires:  c.Expires,
+			Secure:   c.Secure,
+			HTTPOnly: c.HttpOnly,
+		}
+
+		replaced := false
+		for i, e := range existing {
+			if e.Name == record.Name && e.Path == record.Path {
+				existing[i] = record
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			existing = append(existing, record)
+		}
+	}
+
+	j.sessions[session][host] = existing
+}
+
+// Save encodes the entire jar (every session) as compact JSON.
+func (j *CookieJar) Save(w io.Writer) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return json.NewEncoder(w).Encode(j.sessions)
+}
+
+// Load replaces the jar's contents with a JSON encoding previously produced by Save.
+func (j *CookieJar) Load(r io.Reader) error {
+	decoded := make(map[string]map[string][]jarCookie)
+	if err := json.NewDecoder(r).Decode(&decoded); err != nil {
+		return fmt.Errorf("failed to decode cookie jar: %v", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.sessions = decoded
+	return nil
+}
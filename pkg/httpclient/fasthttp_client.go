@@ -0,0 +1,142 @@
+package httpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpproxy"
+	"universal-checker/pkg/types"
+)
+
+// FastHTTPClient is the fasthttp-backed alternative to AzureTLSClient for
+// rule files that opt in via Config.FastMode. It trades away azuretls'
+// browser fingerprinting, redirect policies, and cookie jar support for a
+// request/response path that reuses fasthttp's pooled Request/Response
+// objects instead of allocating one net/http request/response/header-map
+// per check, which is what actually caps CPM at high worker counts.
+type FastHTTPClient struct {
+	client *fasthttp.Client
+	proxy  *types.Proxy
+}
+
+// NewFastHTTPClient creates a FastHTTPClient that dials directly, or through
+// proxy via fasthttpproxy's HTTP or SOCKS5 dialers when proxy is non-nil.
+func NewFastHTTPClient(proxy *types.Proxy, timeout time.Duration) (*FastHTTPClient, error) {
+	client := &fasthttp.Client{
+		ReadTimeout:     timeout,
+		WriteTimeout:    timeout,
+		MaxConnsPerHost: 512,
+	}
+
+	if proxy != nil {
+		addr := fmt.Sprintf("%s:%d", proxy.Host, proxy.Port)
+		if proxy.Username != "" {
+			addr = fmt.Sprintf("%s:%s@%s", proxy.Username, proxy.Password, addr)
+		}
+
+		switch strings.ToLower(string(proxy.Type)) {
+		case "socks5", "socks5h":
+			client.Dial = fasthttpproxy.FasthttpSocksDialer(addr)
+		case "http", "https", "":
+			client.Dial = fasthttpproxy.FasthttpHTTPDialerTimeout(addr, timeout)
+		default:
+			return nil, fmt.Errorf("fasthttp client does not support proxy type %q", proxy.Type)
+		}
+	}
+
+	return &FastHTTPClient{client: client, proxy: proxy}, nil
+}
+
+// Do sends req through the underlying fasthttp.Client and converts the
+// pooled fasthttp.Response back into a *http.Response, copying only the
+// body bytes (everything else is read directly off the response before it's
+// released back to fasthttp's pool).
+func (c *FastHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	freq := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(freq)
+	fresp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(fresp)
+
+	freq.SetRequestURI(req.URL.String())
+	freq.Header.SetMethod(req.Method)
+	for name, values := range req.Header {
+		for _, value := range values {
+			freq.Header.Add(name, value)
+		}
+	}
+
+	if req.Body != nil {
+		bodyBytes, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %v", err)
+		}
+		req.Body.Close()
+		freq.SetBody(bodyBytes)
+	}
+
+	timeout := c.client.ReadTimeout
+	if deadline, ok := req.Context().Deadline(); ok {
+		if remaining := time.Until(deadline); remaining > 0 {
+			timeout = remaining
+		}
+	}
+
+	if err := c.client.DoTimeout(freq, fresp, timeout); err != nil {
+		return nil, err
+	}
+
+	// Body() returns a slice owned by fresp's buffer, which ReleaseResponse
+	// above will recycle - it has to be copied before this function returns.
+	body := append([]byte(nil), fresp.Body()...)
+	statusCode := fresp.StatusCode()
+
+	httpResp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+	fresp.Header.VisitAll(func(key, value []byte) {
+		httpResp.Header.Add(string(key), string(value))
+	})
+
+	return httpResp, nil
+}
+
+// Get performs a GET request.
+func (c *FastHTTPClient) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Post performs a POST request with the given content type and body.
+func (c *FastHTTPClient) Post(rawURL, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest("POST", rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.Do(req)
+}
+
+// PostForm performs a POST request with URL-encoded form data.
+func (c *FastHTTPClient) PostForm(rawURL string, data url.Values) (*http.Response, error) {
+	return c.Post(rawURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+}
+
+// Ensure FastHTTPClient implements HTTPClientInterface.
+var _ HTTPClientInterface = (*FastHTTPClient)(nil)
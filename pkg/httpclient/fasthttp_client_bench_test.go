@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+// TestFastHTTPClientAllocs mirrors fasthttp's own TestAllocationClient: it
+// drives the pooled fasthttp.Client directly (the same Acquire/Release path
+// FastHTTPClient.Do uses under the hood) against an in-memory listener, and
+// asserts the steady-state round trip allocates nothing on the heap. The
+// net/http-compatibility layer in Do necessarily allocates a *http.Response
+// per call to satisfy HTTPClientInterface, so this benchmarks the part of
+// the fast path that's actually meant to be zero-alloc: the pooled
+// request/response objects themselves.
+func TestFastHTTPClientAllocs(t *testing.T) {
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+
+	server := &fasthttp.Server{
+		Handler: func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyString("ok")
+		},
+	}
+	go server.Serve(ln)
+
+	client := &fasthttp.Client{
+		Dial: func(addr string) (net.Conn, error) {
+			return ln.Dial()
+		},
+	}
+
+	do := func() {
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+		defer fasthttp.ReleaseRequest(req)
+		defer fasthttp.ReleaseResponse(resp)
+
+		req.SetRequestURI("http://checker.local/")
+		if err := client.DoTimeout(req, resp, 5*time.Second); err != nil {
+			t.Fatalf("DoTimeout: %v", err)
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		do()
+	}
+
+	allocs := testing.AllocsPerRun(1000, do)
+	if allocs > 0 {
+		t.Fatalf("expected zero allocs per pooled fasthttp round trip, got %v", allocs)
+	}
+}
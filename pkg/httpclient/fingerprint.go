@@ -0,0 +1,153 @@
+package httpclient
+
+import "fmt"
+
+// HTTP2Settings carries the HTTP/2 SETTINGS frame values azuretls applies
+// when establishing a connection for a given fingerprint profile.
+type HTTP2Settings struct {
+	HeaderTableSize      uint32
+	EnablePush           bool
+	MaxConcurrentStreams uint32
+	InitialWindowSize    uint32
+	MaxHeaderListSize    uint32
+
+	// WindowUpdateIncrement is the value sent in the connection-level
+	// WINDOW_UPDATE frame immediately following the SETTINGS frame.
+	WindowUpdateIncrement uint32
+
+	// PriorityFrames describes the PRIORITY frame layout (stream ID,
+	// dependency, weight) browsers send right after their preface.
+	PriorityFrames []string
+}
+
+// FingerprintProfile describes a browser's TLS/HTTP2 fingerprint so
+// AzureTLSClient can impersonate it end-to-end instead of hardcoding Chrome.
+type FingerprintProfile struct {
+	Name string
+
+	// JA3 is the ClientHello string passed to azuretls' ApplyJa3.
+	JA3 string
+	// Navigator is the navigator hint azuretls uses alongside JA3 to pick
+	// matching TLS extensions (e.g. "chrome", "firefox", "safari").
+	Navigator string
+
+	HTTP2 HTTP2Settings
+
+	// PseudoHeaderOrder is the HTTP/2 pseudo-header order (":method",
+	// ":authority", ":scheme", ":path", ...) applied via PHeaderOrder.
+	PseudoHeaderOrder []string
+
+	UserAgent string
+}
+
+var profileRegistry = map[string]FingerprintProfile{}
+
+// RegisterProfile adds or replaces a named fingerprint profile in the
+// registry. Built-in profiles can be overridden by re-registering the
+// same name, which is useful for keeping fingerprints current without
+// touching call sites.
+func RegisterProfile(name string, profile FingerprintProfile) {
+	profileRegistry[name] = profile
+}
+
+// GetProfile looks up a registered fingerprint profile by name.
+func GetProfile(name string) (FingerprintProfile, error) {
+	profile, ok := profileRegistry[name]
+	if !ok {
+		return FingerprintProfile{}, fmt.Errorf("unknown fingerprint profile: %s", name)
+	}
+	return profile, nil
+}
+
+func init() {
+	RegisterProfile("chrome120", FingerprintProfile{
+		Name:      "chrome120",
+		JA3:       "771,4865-4866-4867-49195-49199-49196-49200-52393-52392-49171-49172-156-157-47-53,0-23-65281-10-11-35-16-5-13-18-51-45-43-27-17513,29-23-24,0",
+		Navigator: "chrome",
+		HTTP2: HTTP2Settings{
+			HeaderTableSize:       65536,
+			EnablePush:            false,
+			MaxConcurrentStreams:  1000,
+			InitialWindowSize:     6291456,
+			MaxHeaderListSize:     262144,
+			WindowUpdateIncrement: 15663105,
+			PriorityFrames:        []string{"3:0:0:201", "5:0:0:101", "7:0:0:1", "9:0:7:1", "11:0:3:1", "13:0:0:241"},
+		},
+		PseudoHeaderOrder: []string{":method", ":authority", ":scheme", ":path"},
+		UserAgent:         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	})
+
+	RegisterProfile("firefox120", FingerprintProfile{
+		Name:      "firefox120",
+		JA3:       "771,4865-4867-4866-49195-49199-52393-52392-49196-49200-49162-49161-49171-49172-51-57-47-53,0-23-65281-10-11-35-16-5-34-51-43-13-45-28-65037,29-23-24-25-256-257,0",
+		Navigator: "firefox",
+		HTTP2: HTTP2Settings{
+			HeaderTableSize:       65536,
+			EnablePush:            false,
+			MaxConcurrentStreams:  0,
+			InitialWindowSize:     131072,
+			MaxHeaderListSize:     393216,
+			WindowUpdateIncrement: 12517377,
+			PriorityFrames:        []string{"3:0:0:201", "5:0:0:101", "7:0:0:1", "9:0:7:1", "11:0:3:1", "13:0:0:241"},
+		},
+		PseudoHeaderOrder: []string{":method", ":path", ":authority", ":scheme"},
+		UserAgent:         "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:120.0) Gecko/20100101 Firefox/120.0",
+	})
+
+	RegisterProfile("safari17", FingerprintProfile{
+		Name:      "safari17",
+		JA3:       "771,4865-4866-4867-49196-49195-52393-49200-49199-52392-49162-49161-49172-49171-157-156-53-47,65281-0-23-13-5-18-16-11-10-51-45-43-27-21,29-23-24-25,0",
+		Navigator: "safari",
+		HTTP2: HTTP2Settings{
+			HeaderTableSize:       4096,
+			EnablePush:            false,
+			MaxConcurrentStreams:  100,
+			InitialWindowSize:     2097152,
+			MaxHeaderListSize:     0,
+			WindowUpdateIncrement: 10485760,
+			PriorityFrames:        []string{"3:0:255:1"},
+		},
+		PseudoHeaderOrder: []string{":method", ":scheme", ":path", ":authority"},
+		UserAgent:         "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15",
+	})
+
+	RegisterProfile("ios17", FingerprintProfile{
+		Name:      "ios17",
+		JA3:       "771,4865-4866-4867-49196-49195-52393-49200-49199-52392-49162-49161-49172-49171-157-156-53-47,65281-0-23-13-5-18-16-11-10-51-45-43-27-21,29-23-24-25,0",
+		Navigator: "safari",
+		HTTP2: HTTP2Settings{
+			HeaderTableSize:       4096,
+			EnablePush:            false,
+			MaxConcurrentStreams:  100,
+			InitialWindowSize:     2097152,
+			MaxHeaderListSize:     0,
+			WindowUpdateIncrement: 10485760,
+			PriorityFrames:        []string{"3:0:255:1"},
+		},
+		PseudoHeaderOrder: []string{":method", ":scheme", ":path", ":authority"},
+		UserAgent:         "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+	})
+}
+
+// http2FingerprintString renders the HTTP2Settings into the compact
+// "settings|window_update|priority" fingerprint string azuretls' ApplyHTTP2
+// expects, mirroring the format produced by browser capture tools.
+func (s HTTP2Settings) http2FingerprintString() string {
+	enablePush := 0
+	if s.EnablePush {
+		enablePush = 1
+	}
+
+	settings := fmt.Sprintf("1:%d,2:%d,3:%d,4:%d,6:%d",
+		s.HeaderTableSize, enablePush, s.MaxConcurrentStreams, s.InitialWindowSize, s.MaxHeaderListSize)
+
+	priority := "0"
+	if len(s.PriorityFrames) > 0 {
+		priority = s.PriorityFrames[0]
+		for _, frame := range s.PriorityFrames[1:] {
+			priority += "," + frame
+		}
+	}
+
+	return fmt.Sprintf("%s|%d|%s", settings, s.WindowUpdateIncrement, priority)
+}
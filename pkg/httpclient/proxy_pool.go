@@ -0,0 +1,388 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"universal-checker/pkg/types"
+)
+
+// ProxySelectionStrategy picks which proxy in a ProxyPool handles the next request.
+type ProxySelectionStrategy int
+
+const (
+	// StrategyRoundRobin cycles through proxies in order.
+	StrategyRoundRobin ProxySelectionStrategy = iota
+	// StrategyRandom picks a uniformly random proxy on every request.
+	StrategyRandom
+	// StrategyWeightedBySuccess favors proxies with a higher rolling success ratio.
+	StrategyWeightedBySuccess
+)
+
+// proxyStats tracks rolling health signal for a single pooled proxy.
+type proxyStats struct {
+	latencyEWMA         float64
+	successes           int64
+	failures            int64
+	consecutiveFailures int
+	quarantinedUntil    time.Time
+}
+
+// successRatio returns the proxy's success ratio over everything observed
+// so far, defaulting to 1.0 (optimistic) until it has any history.
+func (s *proxyStats) successRatio() float64 {
+	total := s.successes + s.failures
+	if total == 0 {
+		return 1.0
+	}
+	return float64(s.successes) / float64(total)
+}
+
+// ProxyStatsSnapshot is a read-only view of a pooled proxy's health, returned by ProxyPool.Stats.
+type ProxyStatsSnapshot struct {
+	Proxy       types.Proxy
+	LatencyEWMA time.Duration
+	Successes   int64
+	Failures    int64
+	Quarantined bool
+}
+
+// ProxyPoolConfig configures a ProxyPool.
+type ProxyPoolConfig struct {
+	Strategy ProxySelectionStrategy
+
+	// Profile is the fingerprint profile applied to every pooled AzureTLSClient.
+	Profile string
+	Timeout time.Duration
+
+	// FailureThreshold is the failure ratio (0-1), evaluated once a proxy
+	// has at least MinSamples observations, above which a proxy is quarantined.
+	FailureThreshold float64
+	MinSamples       int64
+
+	// Cooldown is how long a quarantined proxy is skipped before being
+	// eligible for a health-check probe again.
+	Cooldown time.Duration
+
+	// HealthCheckURL, if set, is probed on quarantined proxies once their
+	// cooldown elapses; a successful probe lifts the quarantine.
+	HealthCheckURL string
+
+	// EWMAAlpha is the smoothing factor for the rolling latency average.
+	EWMAAlpha float64
+}
+
+// DefaultProxyPoolConfig returns sane defaults for ProxyPoolConfig.
+func DefaultProxyPoolConfig() ProxyPoolConfig {
+	return ProxyPoolConfig{
+		Strategy:         StrategyRoundRobin,
+		Timeout:          30 * time.Second,
+		FailureThreshold: 0.5,
+		MinSamples:       5,
+		Cooldown:         2 * time.Minute,
+		EWMAAlpha:        0.3,
+	}
+}
+
+// ProxyPool wraps N proxies behind a single HTTPClientInterface, selecting a
+// proxy per-request via a pluggable strategy, reusing a pooled AzureTLSClient
+// per proxy so TLS sessions and HTTP/2 connections aren't rebuilt on every
+// call, and tracking rolling latency/success signal to quarantine bad proxies.
+type ProxyPool struct {
+	config ProxyPoolConfig
+
+	mu      sync.Mutex
+	proxies []*types.Proxy
+	clients map[*types.Proxy]*AzureTLSClient
+	stats   map[*types.Proxy]*proxyStats
+	rrIndex int
+}
+
+// NewProxyPool creates a ProxyPool over the given proxies.
+func NewProxyPool(proxies []*types.Proxy, config ProxyPoolConfig) *ProxyPool {
+	pool := &ProxyPool{
+		config:  config,
+		proxies: append([]*types.Proxy{}, proxies...),
+		clients: make(map[*types.Proxy]*AzureTLSClient),
+		stats:   make(map[*types.Proxy]*proxyStats),
+	}
+	for _, p := range pool.proxies {
+		pool.stats[p] = &proxyStats{}
+	}
+	return pool
+}
+
+// Add registers a new proxy with the pool, e.g. one freshly scraped mid-run.
+func (p *ProxyPool) Add(proxy *types.Proxy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.proxies = append(p.proxies, proxy)
+	p.stats[proxy] = &proxyStats{}
+}
+
+// Remove drops a proxy from the pool and closes its pooled client.
+func (p *ProxyPool) Remove(proxy *types.Proxy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, existing := range p.proxies {
+		if existing == proxy {
+			p.proxies = append(p.proxies[:i], p.proxies[i+1:]...)
+			break
+		}
+	}
+	if client, ok := p.clients[proxy]; ok {
+		client.Close()
+		delete(p.clients, proxy)
+	}
+	delete(p.stats, proxy)
+}
+
+// Stats returns a point-in-time snapshot of every pooled proxy's health.
+func (p *ProxyPool) Stats() []ProxyStatsSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snapshots := make([]ProxyStatsSnapshot, 0, len(p.proxies))
+	for _, proxy := range p.proxies {
+		stats := p.stats[proxy]
+		snapshots = append(snapshots, ProxyStatsSnapshot{
+			Proxy:       *proxy,
+			LatencyEWMA: time.Duration(stats.latencyEWMA) * time.Millisecond,
+			Successes:   stats.successes,
+			Failures:    stats.failures,
+			Quarantined: p.isQuarantined(stats),
+		})
+	}
+	return snapshots
+}
+
+func (p *ProxyPool) isQuarantined(stats *proxyStats) bool {
+	return time.Now().Before(stats.quarantinedUntil)
+}
+
+// pick selects the next proxy to use according to the configured strategy,
+// skipping proxies currently quarantined unless every proxy is quarantined.
+func (p *ProxyPool) pick() (*types.Proxy, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.proxies) == 0 {
+		return nil, fmt.Errorf("proxy pool is empty")
+	}
+
+	available := make([]*types.Proxy, 0, len(p.proxies))
+	for _, proxy := range p.proxies {
+		if !p.isQuarantined(p.stats[proxy]) {
+			available = append(available, proxy)
+		}
+	}
+	if len(available) == 0 {
+		// Every proxy is quarantined; fall back to the full set rather than fail outright.
+		available = p.proxies
+	}
+
+	switch p.config.Strategy {
+	case StrategyRandom:
+		return available[rand.Intn(len(available))], nil
+	case StrategyWeightedBySuccess:
+		return p.pickWeighted(available), nil
+	default:
+		proxy := available[p.rrIndex%len(available)]
+		p.rrIndex = (p.rrIndex + 1) % len(available)
+		return proxy, nil
+	}
+}
+
+// pickWeighted does a weighted-random pick where weight is a proxy's rolling
+// success ratio, so healthier proxies are chosen more often without
+// starving the rest entirely.
+func (p *ProxyPool) pickWeighted(available []*types.Proxy) *types.Proxy {
+	total := 0.0
+	weights := make([]float64, len(available))
+	for i, proxy := range available {
+		w := p.stats[proxy].successRatio()
+		weights[i] = w
+		total += w
+	}
+	if total == 0 {
+		return available[rand.Intn(len(available))]
+	}
+
+	target := rand.Float64() * total
+	for i, w := range weights {
+		target -= w
+		if target <= 0 {
+			return available[i]
+		}
+	}
+	return available[len(available)-1]
+}
+
+// clientFor returns (creating if needed) the pooled AzureTLSClient for a proxy.
+func (p *ProxyPool) clientFor(proxy *types.Proxy) (*AzureTLSClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[proxy]; ok {
+		return client, nil
+	}
+
+	client, err := NewAzureTLSClientWithOptions(ClientOptions{
+		Profile: p.config.Profile,
+		Proxy:   proxy,
+		Timeout: p.config.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.clients[proxy] = client
+	return client, nil
+}
+
+// record updates a proxy's rolling stats after a request, quarantining it
+// once its failure ratio crosses FailureThreshold with enough samples.
+func (p *ProxyPool) record(proxy *types.Proxy, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats[proxy]
+	if stats == nil {
+		return
+	}
+
+	alpha := p.config.EWMAAlpha
+	if alpha <= 0 {
+		alpha = 0.3
+	}
+	sample := float64(latency.Milliseconds())
+	if stats.latencyEWMA == 0 {
+		stats.latencyEWMA = sample
+	} else {
+		stats.latencyEWMA = alpha*sample + (1-alpha)*stats.latencyEWMA
+	}
+
+	if err != nil {
+		stats.failures++
+		stats.consecutiveFailures++
+	} else {
+		stats.successes++
+		stats.consecutiveFailures = 0
+	}
+
+	threshold := p.config.FailureThreshold
+	if threshold <= 0 {
+		threshold = 0.5
+	}
+	minSamples := p.config.MinSamples
+	if minSamples <= 0 {
+		minSamples = 5
+	}
+
+	total := stats.successes + stats.failures
+	if total >= minSamples && (1-stats.successRatio()) > threshold {
+		cooldown := p.config.Cooldown
+		if cooldown <= 0 {
+			cooldown = 2 * time.Minute
+		}
+		stats.quarantinedUntil = time.Now().Add(cooldown)
+	}
+}
+
+// Probe health-checks every quarantined proxy whose cooldown has elapsed
+// against HealthCheckURL, lifting the quarantine on success. It is a no-op
+// if HealthCheckURL isn't configured, and is meant to be called periodically
+// (e.g. from a ticker) by the owner of the pool.
+func (p *ProxyPool) Probe() {
+	if p.config.HealthCheckURL == "" {
+		return
+	}
+
+	p.mu.Lock()
+	var toProbe []*types.Proxy
+	now := time.Now()
+	for _, proxy := range p.proxies {
+		stats := p.stats[proxy]
+		if !stats.quarantinedUntil.IsZero() && !now.Before(stats.quarantinedUntil) {
+			toProbe = append(toProbe, proxy)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, proxy := range toProbe {
+		client, err := p.clientFor(proxy)
+		if err != nil {
+			continue
+		}
+
+		start := time.Now()
+		resp, err := client.Get(p.config.HealthCheckURL)
+		latency := time.Since(start)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		p.record(proxy, latency, err)
+		if err == nil && resp != nil && resp.StatusCode < 500 {
+			p.mu.Lock()
+			p.stats[proxy].quarantinedUntil = time.Time{}
+			p.stats[proxy].consecutiveFailures = 0
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Do picks a proxy via the configured strategy and executes req through its
+// pooled AzureTLSClient, recording latency and success/failure signal.
+func (p *ProxyPool) Do(req *http.Request) (*http.Response, error) {
+	proxy, err := p.pick()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.clientFor(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	p.record(proxy, time.Since(start), err)
+	return resp, err
+}
+
+// Get performs a GET request through the pool.
+func (p *ProxyPool) Get(target string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", target, nil)
+	if err != nil {
+		return nil, err
+	}
+	return p.Do(req)
+}
+
+// Post performs a POST request through the pool.
+func (p *ProxyPool) Post(target, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest("POST", target, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return p.Do(req)
+}
+
+// PostForm performs a POST request with form-encoded data through the pool.
+func (p *ProxyPool) PostForm(target string, data url.Values) (*http.Response, error) {
+	return p.Post(target, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+}
+
+// Ensure ProxyPool implements HTTPClientInterface.
+var _ HTTPClientInterface = (*ProxyPool)(nil)
@@ -0,0 +1,363 @@
+package proxypolicy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"universal-checker/pkg/httpclient"
+	"universal-checker/pkg/types"
+)
+
+// healthEWMAAlpha weights each new probe RTT against the running average,
+// separately from Stats.ewmaLatencyMS since a probe's cadence (seconds to
+// minutes) is much slower than a live request's.
+const healthEWMAAlpha = 0.3
+
+// ProbeConfig configures HealthChecker's active probing of every pooled
+// proxy, modeled on Caddy's reverseproxy healthchecker: a probe URL, an
+// accepted status range, optional body-match assertions, and a per-probe
+// timeout, run on a fixed interval over a bounded worker pool.
+type ProbeConfig struct {
+	// URL is the target every proxy is probed against. An empty URL leaves
+	// active health-checking disabled; HealthChecker.Start becomes a no-op.
+	URL string
+
+	// Interval is how often every proxy is (re-)probed.
+	Interval time.Duration
+	// Timeout bounds a single probe request.
+	Timeout time.Duration
+	// Concurrency caps how many proxies are probed in parallel; 0 defaults to 8.
+	Concurrency int
+
+	// MinStatus/MaxStatus bound the accepted response status range,
+	// inclusive; 0/0 defaults to 200-399.
+	MinStatus int
+	MaxStatus int
+
+	// BodySubstring, if set, must appear in the probe response body.
+	BodySubstring string
+	// BodyPattern, if set, must match the probe response body.
+	BodyPattern *regexp.Regexp
+
+	// EjectAfterFailures is the number of consecutive probe or passive
+	// failures before a proxy is ejected for Cooldown; 0 defaults to 3.
+	EjectAfterFailures int
+	// Cooldown is how long an ejected proxy is skipped before it's eligible
+	// for selection again (it's still probed in the meantime).
+	Cooldown time.Duration
+}
+
+// DefaultProbeConfig returns sane defaults for ProbeConfig; callers still
+// need to set URL to enable active probing.
+func DefaultProbeConfig() ProbeConfig {
+	return ProbeConfig{
+		Interval:           30 * time.Second,
+		Timeout:            10 * time.Second,
+		Concurrency:        8,
+		MinStatus:          200,
+		MaxStatus:          399,
+		EjectAfterFailures: 3,
+		Cooldown:           2 * time.Minute,
+	}
+}
+
+// ProxyHealth is a point-in-time snapshot of a single proxy's health, fed by
+// both HealthChecker's active probes and passive ReportResult calls from
+// live traffic.
+type ProxyHealth struct {
+	Healthy             bool
+	ConsecutiveFailures int
+	LastRTT             time.Duration
+	LastCheck           time.Time
+	UptimeRatio         float64
+	EWMALatencyMS       float64
+	LastError           string
+	EjectedUntil        time.Time
+}
+
+// healthRecord is the mutable, mutex-guarded state ProxyHealth snapshots.
+type healthRecord struct {
+	consecutiveFailures int
+	totalChecks         int64
+	healthyChecks       int64
+	ewmaLatencyMS       float64
+	lastRTT             time.Duration
+	lastError           string
+	lastCheck           time.Time
+	ejectedUntil        time.Time
+}
+
+// HealthChecker actively probes a pool of proxies on ProbeConfig.Interval
+// and also accepts passive results from live traffic via ReportResult, so a
+// proxy that starts failing real requests is ejected for Cooldown before its
+// next scheduled probe rather than waiting it out.
+type HealthChecker struct {
+	probe ProbeConfig
+
+	mu      sync.Mutex
+	records map[string]*healthRecord
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewHealthChecker creates a HealthChecker from probe. Start is a no-op
+// until probe.URL is set.
+func NewHealthChecker(probe ProbeConfig) *HealthChecker {
+	return &HealthChecker{
+		probe:   probe,
+		records: make(map[string]*healthRecord),
+	}
+}
+
+// Start begins probing on a background goroutine, calling proxies before
+// each round to pick up additions/removals since the last round. It's a
+// no-op if ProbeConfig.URL isn't set.
+func (h *HealthChecker) Start(proxies func() []types.Proxy) {
+	if h.probe.URL == "" {
+		return
+	}
+
+	h.stopCh = make(chan struct{})
+	h.wg.Add(1)
+	go h.run(proxies)
+}
+
+// Stop halts probing and waits for the in-flight round to finish.
+func (h *HealthChecker) Stop() {
+	if h.stopCh == nil {
+		return
+	}
+	close(h.stopCh)
+	h.wg.Wait()
+}
+
+func (h *HealthChecker) run(proxies func() []types.Proxy) {
+	defer h.wg.Done()
+
+	interval := h.probe.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.stopCh:
+			return
+		case <-ticker.C:
+			h.probeAll(proxies())
+		}
+	}
+}
+
+// probeAll probes every proxy in proxies concurrently, bounded by
+// ProbeConfig.Concurrency.
+func (h *HealthChecker) probeAll(proxies []types.Proxy) {
+	concurrency := h.probe.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, proxy := range proxies {
+		proxy := proxy
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			h.probeOne(proxy)
+		}()
+	}
+	wg.Wait()
+}
+
+// probeOne runs a single probe request through proxy and records the result.
+func (h *HealthChecker) probeOne(proxy types.Proxy) {
+	key := ProxyKey(proxy)
+
+	client, err := httpclient.NewAzureTLSClient(&proxy, h.probe.Timeout)
+	if err != nil {
+		h.recordFailure(key, err)
+		return
+	}
+
+	start := time.Now()
+	resp, err := client.Get(h.probe.URL)
+	rtt := time.Since(start)
+	if err != nil {
+		h.recordFailure(key, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if !h.statusOK(resp.StatusCode) {
+		h.recordFailure(key, fmt.Errorf("unexpected probe status %d", resp.StatusCode))
+		return
+	}
+
+	if h.probe.BodySubstring != "" || h.probe.BodyPattern != nil {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		if !h.bodyOK(body) {
+			h.recordFailure(key, fmt.Errorf("probe response body did not match expectation"))
+			return
+		}
+	}
+
+	h.recordSuccess(key, rtt)
+}
+
+func (h *HealthChecker) statusOK(code int) bool {
+	min, max := h.probe.MinStatus, h.probe.MaxStatus
+	if min == 0 {
+		min = 200
+	}
+	if max == 0 {
+		max = 399
+	}
+	return code >= min && code <= max
+}
+
+func (h *HealthChecker) bodyOK(body []byte) bool {
+	if h.probe.BodySubstring != "" && !bytes.Contains(body, []byte(h.probe.BodySubstring)) {
+		return false
+	}
+	if h.probe.BodyPattern != nil && !h.probe.BodyPattern.Match(body) {
+		return false
+	}
+	return true
+}
+
+// ReportResult feeds a passive transport result observed outside the active
+// probe loop (a checker task's own request through proxy) into the same
+// health record, so repeated real-world failures eject a proxy for Cooldown
+// even between probes, and a real success clears its failure streak without
+// waiting for the next scheduled probe.
+func (h *HealthChecker) ReportResult(proxy types.Proxy, latency time.Duration, err error) {
+	key := ProxyKey(proxy)
+	if err != nil {
+		h.recordFailure(key, err)
+		return
+	}
+	h.recordSuccess(key, latency)
+}
+
+func (h *HealthChecker) recordSuccess(key string, rtt time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r := h.recordFor(key)
+	r.totalChecks++
+	r.healthyChecks++
+	r.consecutiveFailures = 0
+	r.lastRTT = rtt
+	r.lastError = ""
+	r.lastCheck = time.Now()
+	r.ejectedUntil = time.Time{}
+
+	sample := float64(rtt.Milliseconds())
+	if r.ewmaLatencyMS == 0 {
+		r.ewmaLatencyMS = sample
+	} else {
+		r.ewmaLatencyMS = healthEWMAAlpha*sample + (1-healthEWMAAlpha)*r.ewmaLatencyMS
+	}
+}
+
+func (h *HealthChecker) recordFailure(key string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r := h.recordFor(key)
+	r.totalChecks++
+	r.consecutiveFailures++
+	r.lastError = err.Error()
+	r.lastCheck = time.Now()
+
+	ejectAfter := h.probe.EjectAfterFailures
+	if ejectAfter <= 0 {
+		ejectAfter = 3
+	}
+	if r.consecutiveFailures >= ejectAfter {
+		cooldown := h.probe.Cooldown
+		if cooldown <= 0 {
+			cooldown = 2 * time.Minute
+		}
+		r.ejectedUntil = time.Now().Add(cooldown)
+	}
+}
+
+// recordFor returns the record for key, creating it on first use. Callers
+// must hold h.mu.
+func (h *HealthChecker) recordFor(key string) *healthRecord {
+	r, ok := h.records[key]
+	if !ok {
+		r = &healthRecord{}
+		h.records[key] = r
+	}
+	return r
+}
+
+// IsHealthy reports whether proxy is currently eligible for selection, i.e.
+// it either has no history yet or isn't within its ejection cooldown.
+func (h *HealthChecker) IsHealthy(proxy types.Proxy) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.records[ProxyKey(proxy)]
+	if !ok {
+		return true
+	}
+	return time.Now().After(r.ejectedUntil)
+}
+
+// Snapshot returns the current health of proxy, defaulting to a healthy,
+// zero-valued ProxyHealth if it has never been probed or reported on.
+func (h *HealthChecker) Snapshot(proxy types.Proxy) ProxyHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.records[ProxyKey(proxy)]
+	if !ok {
+		return ProxyHealth{Healthy: true}
+	}
+	return h.toHealth(r)
+}
+
+// Table returns a point-in-time snapshot of every tracked proxy's health,
+// keyed the same way ProxyKey does, for Checker.GetStats and the admin API's
+// JSON health endpoint.
+func (h *HealthChecker) Table() map[string]ProxyHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	table := make(map[string]ProxyHealth, len(h.records))
+	for key, r := range h.records {
+		table[key] = h.toHealth(r)
+	}
+	return table
+}
+
+// toHealth converts r into a ProxyHealth snapshot. Callers must hold h.mu.
+func (h *HealthChecker) toHealth(r *healthRecord) ProxyHealth {
+	uptime := 1.0
+	if r.totalChecks > 0 {
+		uptime = float64(r.healthyChecks) / float64(r.totalChecks)
+	}
+	return ProxyHealth{
+		Healthy:             time.Now().After(r.ejectedUntil),
+		ConsecutiveFailures: r.consecutiveFailures,
+		LastRTT:             r.lastRTT,
+		LastCheck:           r.lastCheck,
+		UptimeRatio:         uptime,
+		EWMALatencyMS:       r.ewmaLatencyMS,
+		LastError:           r.lastError,
+		EjectedUntil:        r.ejectedUntil,
+	}
+}
@@ -0,0 +1,185 @@
+package proxypolicy
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+
+	"universal-checker/pkg/types"
+)
+
+// RoundRobinPolicy cycles through proxies in order, wrapping back to the
+// start. It's the direct replacement for getNextProxy's old
+// config.ProxyRotation branch.
+type RoundRobinPolicy struct {
+	mu    sync.Mutex
+	index int
+}
+
+// NewRoundRobinPolicy creates a RoundRobinPolicy starting at the first proxy.
+func NewRoundRobinPolicy() *RoundRobinPolicy {
+	return &RoundRobinPolicy{}
+}
+
+func (p *RoundRobinPolicy) Select(proxies []types.Proxy, _ SelectionContext) (*types.Proxy, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proxy := &proxies[p.index%len(proxies)]
+	p.index = (p.index + 1) % len(proxies)
+	return proxy, nil
+}
+
+// RandomPolicy picks a uniformly random proxy. It's the direct replacement
+// for getNextProxy's old non-rotation branch.
+type RandomPolicy struct{}
+
+func (RandomPolicy) Select(proxies []types.Proxy, _ SelectionContext) (*types.Proxy, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+	return &proxies[rand.Intn(len(proxies))], nil
+}
+
+// WeightedRoundRobinPolicy favors proxies with a higher overall success rate
+// and lower EWMA latency, drawing proportionally to that weight rather than
+// picking the single best proxy every time.
+type WeightedRoundRobinPolicy struct {
+	stats *Stats
+}
+
+// NewWeightedRoundRobinPolicy creates a policy that weights proxies using
+// the success/latency signal tracked in stats.
+func NewWeightedRoundRobinPolicy(stats *Stats) *WeightedRoundRobinPolicy {
+	return &WeightedRoundRobinPolicy{stats: stats}
+}
+
+func (p *WeightedRoundRobinPolicy) Select(proxies []types.Proxy, _ SelectionContext) (*types.Proxy, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+
+	weights := make([]float64, len(proxies))
+	var total float64
+	for i, proxy := range proxies {
+		weights[i] = proxyWeight(p.stats.Snapshot(ProxyKey(proxy)))
+		total += weights[i]
+	}
+	if total <= 0 {
+		return &proxies[rand.Intn(len(proxies))], nil
+	}
+
+	draw := rand.Float64() * total
+	for i, w := range weights {
+		if draw < w {
+			return &proxies[i], nil
+		}
+		draw -= w
+	}
+	return &proxies[len(proxies)-1], nil
+}
+
+// proxyWeight combines overall success rate and latency into a single score:
+// a proxy with no history yet is treated as average (0.5 success rate) so it
+// gets a fair chance to prove itself instead of starving at zero.
+func proxyWeight(m ProxyMetrics) float64 {
+	successRate := 0.5
+	if total := m.SuccessCount + m.FailureCount; total > 0 {
+		successRate = float64(m.SuccessCount) / float64(total)
+	}
+
+	latencyFactor := 1.0
+	if m.EWMALatencyMS > 0 {
+		latencyFactor = 1000.0 / (1000.0 + m.EWMALatencyMS)
+	}
+
+	weight := successRate * latencyFactor
+	if weight <= 0 {
+		// Keep a small floor so a proxy with zero recent successes can
+		// still be drawn occasionally and recover.
+		weight = 0.01
+	}
+	return weight
+}
+
+// LeastConnPolicy picks the proxy with the fewest in-flight requests,
+// spreading load away from proxies that are currently busy or stuck.
+type LeastConnPolicy struct {
+	stats *Stats
+}
+
+// NewLeastConnPolicy creates a policy that reads in-flight counts from stats.
+func NewLeastConnPolicy(stats *Stats) *LeastConnPolicy {
+	return &LeastConnPolicy{stats: stats}
+}
+
+func (p *LeastConnPolicy) Select(proxies []types.Proxy, _ SelectionContext) (*types.Proxy, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+
+	best := &proxies[0]
+	bestInFlight := p.stats.Snapshot(ProxyKey(*best)).InFlight
+	for i := 1; i < len(proxies); i++ {
+		if inFlight := p.stats.Snapshot(ProxyKey(proxies[i])).InFlight; inFlight < bestInFlight {
+			best = &proxies[i]
+			bestInFlight = inFlight
+		}
+	}
+	return best, nil
+}
+
+// FirstAvailablePolicy returns the first Working proxy in list order, so
+// configs can rank proxies paid-tier-first by ordering c.Proxies that way.
+type FirstAvailablePolicy struct{}
+
+func (FirstAvailablePolicy) Select(proxies []types.Proxy, _ SelectionContext) (*types.Proxy, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+	for i := range proxies {
+		if proxies[i].Working {
+			return &proxies[i], nil
+		}
+	}
+	// Nothing is marked Working; fall back to the first entry rather than
+	// failing the task outright.
+	return &proxies[0], nil
+}
+
+// IPHashPolicy hashes the combo's username to a stable proxy index, giving
+// the same combo session affinity to the same proxy across retries.
+type IPHashPolicy struct{}
+
+func (IPHashPolicy) Select(proxies []types.Proxy, selCtx SelectionContext) (*types.Proxy, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+	return &proxies[hashIndex(selCtx.ComboUsername, len(proxies))], nil
+}
+
+// HeaderHashPolicy hashes a config-named header's value to a stable proxy
+// index, giving requests that share that header value session affinity.
+type HeaderHashPolicy struct{}
+
+func (HeaderHashPolicy) Select(proxies []types.Proxy, selCtx SelectionContext) (*types.Proxy, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+	return &proxies[hashIndex(selCtx.HeaderValue, len(proxies))], nil
+}
+
+// hashIndex maps value to a proxy index in [0, n) via FNV-1a, falling back
+// to index 0 for an empty value instead of letting every empty-value
+// request collide on whatever index 0 happens to hash to.
+func hashIndex(value string, n int) int {
+	if value == "" {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(value))
+	return int(h.Sum32()) % n
+}
@@ -0,0 +1,37 @@
+// Package proxypolicy implements pluggable proxy selection strategies,
+// modeled after Caddy's reverse_proxy load-balancing policies. It exists so
+// Checker.getNextProxy's fallback path - hit whenever AdvancedProxyManager
+// has no opinion yet - can pick a config-selectable strategy instead of the
+// hard-coded sequential/random branch it used to have.
+package proxypolicy
+
+import (
+	"errors"
+
+	"universal-checker/pkg/types"
+)
+
+// ErrNoProxies is returned by Select when proxies is empty.
+var ErrNoProxies = errors.New("proxypolicy: no proxies available")
+
+// SelectionContext carries the per-request signal that session-affinity
+// policies (IPHash, HeaderHash) hash against. Policies that don't need it
+// (RoundRobin, Random, ...) simply ignore the fields they don't use.
+type SelectionContext struct {
+	// ComboUsername identifies the combo being checked, used by IPHash to
+	// keep one username pinned to the same proxy across retries.
+	ComboUsername string
+
+	// HeaderValue is the value of whatever header HeaderHash was configured
+	// to hash (config.ProxyPolicyHeaderName), resolved by the caller before
+	// Select is invoked.
+	HeaderValue string
+}
+
+// ProxySelectionPolicy picks one proxy out of proxies for a single request.
+// Implementations must be safe for concurrent use, since Checker resolves
+// one shared instance per policy name (see Registry), across all configs,
+// and calls Select from every worker goroutine.
+type ProxySelectionPolicy interface {
+	Select(proxies []types.Proxy, selCtx SelectionContext) (*types.Proxy, error)
+}
@@ -0,0 +1,65 @@
+package proxypolicy
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Registry resolves a policy name to a shared ProxySelectionPolicy instance,
+// caching it so stateful policies (RoundRobin's index, WeightedRoundRobin's
+// shared Stats) persist across calls instead of resetting every request.
+type Registry struct {
+	stats *Stats
+
+	mu       sync.Mutex
+	policies map[string]ProxySelectionPolicy
+}
+
+// NewRegistry creates a Registry whose weighted/least-conn policies read
+// their signal from stats.
+func NewRegistry(stats *Stats) *Registry {
+	return &Registry{stats: stats, policies: make(map[string]ProxySelectionPolicy)}
+}
+
+// Resolve returns the cached policy for name, constructing it on first use.
+// An empty name resolves to "round_robin".
+func (r *Registry) Resolve(name string) (ProxySelectionPolicy, error) {
+	if name == "" {
+		name = "round_robin"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if policy, ok := r.policies[name]; ok {
+		return policy, nil
+	}
+
+	policy, err := newPolicy(name, r.stats)
+	if err != nil {
+		return nil, err
+	}
+	r.policies[name] = policy
+	return policy, nil
+}
+
+func newPolicy(name string, stats *Stats) (ProxySelectionPolicy, error) {
+	switch name {
+	case "round_robin":
+		return NewRoundRobinPolicy(), nil
+	case "weighted_round_robin":
+		return NewWeightedRoundRobinPolicy(stats), nil
+	case "least_conn":
+		return NewLeastConnPolicy(stats), nil
+	case "random":
+		return RandomPolicy{}, nil
+	case "first_available":
+		return FirstAvailablePolicy{}, nil
+	case "ip_hash":
+		return IPHashPolicy{}, nil
+	case "header_hash":
+		return HeaderHashPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("proxypolicy: unknown policy %q", name)
+	}
+}
@@ -0,0 +1,107 @@
+package proxypolicy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"universal-checker/pkg/types"
+)
+
+// ewmaAlpha weights each new latency sample against the running average.
+// 0.2 settles within a handful of requests while still smoothing out a
+// single slow outlier, which is what WeightedRoundRobin needs.
+const ewmaAlpha = 0.2
+
+// ProxyMetrics is a point-in-time snapshot of the signal Stats tracks for a
+// single proxy.
+type ProxyMetrics struct {
+	InFlight      int64
+	EWMALatencyMS float64
+	SuccessCount  uint64
+	FailureCount  uint64
+}
+
+type proxyCounters struct {
+	inFlight      int64
+	ewmaLatencyMS float64
+	successCount  uint64
+	failureCount  uint64
+}
+
+// Stats tracks per-proxy in-flight request counts, an EWMA of request
+// latency, and success/failure totals counted since the proxy was first
+// seen (they are never reset or windowed) - the "real signal" that
+// WeightedRoundRobin and LeastConn select against in place of the simple
+// sequential/random fallback they replace.
+type Stats struct {
+	mu       sync.Mutex
+	counters map[string]*proxyCounters
+}
+
+// NewStats creates an empty Stats ready to track any number of proxies.
+func NewStats() *Stats {
+	return &Stats{counters: make(map[string]*proxyCounters)}
+}
+
+// ProxyKey derives the map key Stats uses to identify a proxy, so Checker
+// and every policy implementation agree on proxy identity.
+func ProxyKey(proxy types.Proxy) string {
+	return fmt.Sprintf("%s:%d", proxy.Host, proxy.Port)
+}
+
+// BeginRequest marks the start of a request against the proxy identified by
+// key, incrementing its in-flight counter, and returns a func to call once
+// the request finishes with whether it succeeded and how long it took.
+func (s *Stats) BeginRequest(key string) func(success bool, latency time.Duration) {
+	s.mu.Lock()
+	c := s.counterFor(key)
+	c.inFlight++
+	s.mu.Unlock()
+
+	return func(success bool, latency time.Duration) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		c.inFlight--
+		if c.ewmaLatencyMS == 0 {
+			c.ewmaLatencyMS = float64(latency.Milliseconds())
+		} else {
+			c.ewmaLatencyMS = ewmaAlpha*float64(latency.Milliseconds()) + (1-ewmaAlpha)*c.ewmaLatencyMS
+		}
+		if success {
+			c.successCount++
+		} else {
+			c.failureCount++
+		}
+	}
+}
+
+// Snapshot returns the current metrics for key, zero-valued if key has
+// never been seen.
+func (s *Stats) Snapshot(key string) ProxyMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		return ProxyMetrics{}
+	}
+	return ProxyMetrics{
+		InFlight:      c.inFlight,
+		EWMALatencyMS: c.ewmaLatencyMS,
+		SuccessCount:  c.successCount,
+		FailureCount:  c.failureCount,
+	}
+}
+
+// counterFor returns the counters for key, creating them on first use.
+// Callers must hold s.mu.
+func (s *Stats) counterFor(key string) *proxyCounters {
+	c, ok := s.counters[key]
+	if !ok {
+		c = &proxyCounters{}
+		s.counters[key] = c
+	}
+	return c
+}
@@ -0,0 +1,96 @@
+package streamserver
+
+import "sync"
+
+// defaultSubscriberBuffer is how many pending messages a slow subscriber is
+// allowed to accumulate before the broadcaster starts dropping its oldest
+// unsent message rather than blocking the whole fan-out.
+const defaultSubscriberBuffer = 64
+
+// topic identifies one of the three broadcast streams. Subscribers only ever
+// see messages published on the topic they subscribed to.
+type topic int
+
+const (
+	topicResults topic = iota
+	topicStats
+	topicLogs
+)
+
+// subscriber is a single connected client's mailbox for a topic. send is
+// buffered; publish drops the oldest queued message instead of blocking when
+// the client can't keep up.
+type subscriber struct {
+	send chan []byte
+}
+
+// hub fans messages out to per-subscriber buffered channels, dropping the
+// oldest queued message for a subscriber that's fallen behind rather than
+// blocking the publisher (a slow dashboard shouldn't stall the checker).
+type hub struct {
+	mu          sync.RWMutex
+	subscribers map[topic]map[*subscriber]struct{}
+}
+
+func newHub() *hub {
+	return &hub{
+		subscribers: map[topic]map[*subscriber]struct{}{
+			topicResults: {},
+			topicStats:   {},
+			topicLogs:    {},
+		},
+	}
+}
+
+// subscribe registers a new subscriber for t and returns it. The caller must
+// unsubscribe when the connection closes.
+func (h *hub) subscribe(t topic) *subscriber {
+	sub := &subscriber{send: make(chan []byte, defaultSubscriberBuffer)}
+
+	h.mu.Lock()
+	h.subscribers[t][sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+// unsubscribe removes sub from t and closes its mailbox.
+func (h *hub) unsubscribe(t topic, sub *subscriber) {
+	h.mu.Lock()
+	delete(h.subscribers[t], sub)
+	h.mu.Unlock()
+
+	close(sub.send)
+}
+
+// publish delivers payload to every subscriber of t, dropping the oldest
+// buffered message for any subscriber whose mailbox is full.
+func (h *hub) publish(t topic, payload []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers[t] {
+		select {
+		case sub.send <- payload:
+		default:
+			// Slow subscriber: drop its oldest queued message and retry once.
+			select {
+			case <-sub.send:
+			default:
+			}
+			select {
+			case sub.send <- payload:
+			default:
+			}
+		}
+	}
+}
+
+// subscriberCount reports how many clients are currently attached to t,
+// mainly so PublishStats and the /ws/logs sink can skip encoding when
+// nobody's listening.
+func (h *hub) subscriberCount(t topic) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[t])
+}
@@ -0,0 +1,39 @@
+package streamserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"universal-checker/internal/logger"
+)
+
+// logSink adapts Server to logger.LogExporter so StructuredLogger can fan
+// entries out to /ws/logs subscribers the same way it fans out to a file or
+// webhook sink.
+type logSink struct {
+	server *Server
+}
+
+// Export publishes each entry to every connected /ws/logs subscriber.
+// Entries are dropped silently if nobody's listening, same as any other
+// unread topic.
+func (s *logSink) Export(ctx context.Context, entries []logger.LogEntry) error {
+	if s.server.hub.subscriberCount(topicLogs) == 0 {
+		return nil
+	}
+	for _, entry := range entries {
+		payload, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		s.server.hub.publish(topicLogs, payload)
+	}
+	return nil
+}
+
+// Flush is a no-op: entries are delivered synchronously as they're exported.
+func (s *logSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+var _ logger.LogExporter = (*logSink)(nil)
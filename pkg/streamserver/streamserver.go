@@ -0,0 +1,166 @@
+// Package streamserver exposes live WebSocket feeds (results, stats, logs)
+// for a running checker.Checker, so dashboards and remote TUIs can observe a
+// run in flight instead of polling GetStats or waiting for the final summary.
+package streamserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"universal-checker/pkg/types"
+)
+
+// defaultMaxMessageBytes is the default WebSocket read limit set on each
+// connection; callers can change it via Config.MaxMessageBytes. Outbound
+// frames, such as a CheckResult carrying a full HTML/JSON Response body, are
+// not size-capped.
+const defaultMaxMessageBytes = 1 << 20 // 1 MiB
+
+// Config controls the listener and per-connection limits.
+type Config struct {
+	// Addr is the address the HTTP server listens on, e.g. ":8090".
+	Addr string
+
+	// MaxMessageBytes is the read limit set on each connection; it does not
+	// cap outbound frames. Defaults to 1 MiB when zero.
+	MaxMessageBytes int64
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxMessageBytes <= 0 {
+		c.MaxMessageBytes = defaultMaxMessageBytes
+	}
+	return c
+}
+
+// Server hosts the /ws/results, /ws/stats and /ws/logs endpoints. It's
+// started alongside the checker's worker pool and torn down on Stop, mirroring
+// the lifecycle of the checker's other auxiliary subsystems.
+type Server struct {
+	config     Config
+	hub        *hub
+	upgrader   websocket.Upgrader
+	httpServer *http.Server
+}
+
+// NewServer creates a Server; call Start to begin listening.
+func NewServer(config Config) *Server {
+	config = config.withDefaults()
+
+	return &Server{
+		config: config,
+		hub:    newHub(),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			// Dashboards and TUIs are expected to connect cross-origin; the
+			// checker itself has no session/cookie auth to protect here.
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Start begins listening in a background goroutine. Errors after the
+// listener is up (including a clean Shutdown) are silently dropped, matching
+// how the checker's other background loops report failures via c.logger
+// rather than a returned error.
+func (s *Server) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/results", s.handleWS(topicResults))
+	mux.HandleFunc("/ws/stats", s.handleWS(topicStats))
+	mux.HandleFunc("/ws/logs", s.handleWS(topicLogs))
+
+	s.httpServer = &http.Server{
+		Addr:    s.config.Addr,
+		Handler: mux,
+	}
+
+	ln, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("streamserver: failed to listen on %s: %v", s.config.Addr, err)
+	}
+
+	go s.httpServer.Serve(ln)
+	return nil
+}
+
+// Stop gracefully shuts the listener down, closing every open connection.
+func (s *Server) Stop() {
+	if s.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.httpServer.Shutdown(ctx)
+}
+
+// PublishResult broadcasts result to every /ws/results subscriber. It's
+// meant to be called from handleResult as each CheckResult leaves the
+// result-processing goroutine.
+func (s *Server) PublishResult(result types.CheckResult) {
+	s.publishJSON(topicResults, result)
+}
+
+// PublishStats broadcasts a Stats snapshot to every /ws/stats subscriber.
+// Callers should take their own snapshot (e.g. via Checker.GetStats, which
+// already holds statsMutex for the read) before calling this.
+func (s *Server) PublishStats(stats types.CheckerStats) {
+	if s.hub.subscriberCount(topicStats) == 0 {
+		return
+	}
+	s.publishJSON(topicStats, stats)
+}
+
+// LogSink returns a logger.LogExporter that fans entries out to /ws/logs
+// subscribers. Register it with StructuredLogger.RegisterExporter.
+func (s *Server) LogSink() *logSink {
+	return &logSink{server: s}
+}
+
+func (s *Server) publishJSON(t topic, v interface{}) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	s.hub.publish(t, payload)
+}
+
+// handleWS upgrades the request and pumps hub messages for t to the
+// connection until it closes or the write fails.
+func (s *Server) handleWS(t topic) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := s.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.SetReadLimit(s.config.MaxMessageBytes)
+
+		sub := s.hub.subscribe(t)
+		defer s.hub.unsubscribe(t, sub)
+
+		// Drain (and discard) client reads so ping/pong control frames and
+		// disconnects are observed; these endpoints are publish-only.
+		go func() {
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}()
+
+		for payload := range sub.send {
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		}
+	}
+}